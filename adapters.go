@@ -0,0 +1,160 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+// WalkDirFunc wraps fn so that the errors it returns are collected instead of
+// aborting the walk.
+//
+// Returning an error from an fs.WalkDirFunc stops fs.WalkDir, while returning
+// nil loses the error. The returned function appends every non-nil error
+// returned by fn to c and returns nil to the walker, so the walk continues
+// and every failure is reported:
+//
+//	c := rxmerr.NewCollector()
+//	_ = fs.WalkDir(fsys, ".", c.WalkDirFunc(func(path string, d fs.DirEntry, err error) error {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    return loadConfig(fsys, path)
+//	}))
+//	return c.Err()
+//
+// fs.SkipDir and fs.SkipAll are control values rather than failures: they
+// are passed through to the walker unchanged and are not collected. As in
+// fs.WalkDir, they are recognized by identity, not with errors.Is.
+func (c *Collector) WalkDirFunc(fn fs.WalkDirFunc) fs.WalkDirFunc {
+	return func(path string, d fs.DirEntry, err error) error {
+		err = fn(path, d, err)
+		if err == fs.SkipDir || err == fs.SkipAll {
+			return err
+		}
+		c.Append(err)
+		return nil
+	}
+}
+
+// Swallow adapts a callback for APIs that stop at the first error: the
+// returned function calls fn, appends its error (if any) to c, and always
+// returns nil.
+//
+//	err := parser.Each(rxmerr.Swallow(c, validateRecord))
+//
+// Swallow is the generic counterpart of Collector.WalkDirFunc for callbacks
+// that take a single argument.
+func Swallow[T any](c *Collector, fn func(T) error) func(T) error {
+	return func(v T) error {
+		c.Append(fn(v))
+		return nil
+	}
+}
+
+// AppendCloseAll calls Close on every non-nil closer in order and appends
+// the failures to c, each prefixed with the index of its closer:
+//
+//	defer c.AppendCloseAll(conns...)
+//	// c.Errors() contains, for example, "closer 2: connection reset"
+//
+// Every closer is closed, regardless of earlier failures. Nil closers are
+// skipped but keep their index, so the prefix always refers to the position
+// in closers. Close is not guarded against panics; see DoWithRecover.
+func (c *Collector) AppendCloseAll(closers ...io.Closer) {
+	for i, closer := range closers {
+		if closer == nil {
+			continue
+		}
+		if err := closer.Close(); err != nil {
+			c.Append(fmt.Errorf("closer %d: %w", i, err))
+		}
+	}
+}
+
+// CollectingWriter is an io.Writer that collects every line written to it as
+// an error, bridging libraries that report problems by writing to a writer,
+// such as a log.Logger:
+//
+//	w := rxmerr.NewCollectingWriter()
+//	lib.SetErrorOutput(log.New(w, "", 0))
+//	lib.Run()
+//	w.Flush()
+//	return w.Err()
+//
+// Each newline-terminated line becomes an error whose message is the line
+// without its line ending ("\n" or "\r\n"); empty lines are skipped. A line may
+// span several writes: the unterminated tail of a write is buffered until a
+// later write completes it, or until Flush.
+//
+// The errors are appended to the embedded Collector, whose methods, such as
+// Err, Errors, and Len, are available on the writer. The zero value is a
+// CollectingWriter ready for use. Like Collector, CollectingWriter is not safe
+// for concurrent use.
+type CollectingWriter struct {
+	Collector
+
+	partial []byte // unterminated tail of the written data
+}
+
+// NewCollectingWriter creates a CollectingWriter whose collector is
+// configured with opts, see NewCollector.
+func NewCollectingWriter(opts ...Option) *CollectingWriter {
+	w := &CollectingWriter{}
+	// Options are applied to the embedded collector in place: some of them
+	// capture the collector they configure, which a copy would leave behind.
+	for _, opt := range opts {
+		opt(&w.Collector)
+	}
+	return w
+}
+
+// Write implements io.Writer. It appends every line completed by p to the
+// collector and buffers the rest. It always returns len(p), nil.
+func (w *CollectingWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			break
+		}
+		w.partial = append(w.partial, p[:i]...)
+		w.appendLine()
+		p = p[i+1:]
+	}
+	w.partial = append(w.partial, p...)
+	return n, nil
+}
+
+// Flush appends the buffered unterminated line, if any, to the collector.
+func (w *CollectingWriter) Flush() {
+	w.appendLine()
+}
+
+// appendLine appends the buffered line to the collector, unless it is empty,
+// and clears the buffer.
+func (w *CollectingWriter) appendLine() {
+	line := bytes.TrimSuffix(w.partial, []byte("\r"))
+	if len(line) > 0 {
+		w.Append(errors.New(string(line)))
+	}
+	w.partial = w.partial[:0]
+}
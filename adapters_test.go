@@ -0,0 +1,226 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"dirpx.dev/rxmerr"
+)
+
+// loadConfig fails for files whose content is not "ok".
+func loadConfig(fsys fs.FS, name string, d fs.DirEntry) error {
+	if d.IsDir() {
+		return nil
+	}
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return err
+	}
+	if string(data) != "ok" {
+		return fmt.Errorf("%s: invalid config", name)
+	}
+	return nil
+}
+
+func TestWalkDirFunc(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.conf":          {Data: []byte("ok")},
+		"b.conf":          {Data: []byte("broken")},
+		"sub/c.conf":      {Data: []byte("broken")},
+		"sub/d.conf":      {Data: []byte("ok")},
+		"vendor/e.conf":   {Data: []byte("broken")},
+		"zzz/stop/f.conf": {Data: []byte("broken")},
+	}
+
+	c := rxmerr.NewCollector()
+	var visited []string
+	err := fs.WalkDir(fsys, ".", c.WalkDirFunc(func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		visited = append(visited, name)
+		switch {
+		case name == "vendor":
+			return fs.SkipDir
+		case name == "zzz/stop":
+			return fs.SkipAll
+		}
+		return loadConfig(fsys, name, d)
+	}))
+	if err != nil {
+		t.Fatalf("WalkDir() = %v, want nil", err)
+	}
+
+	want := []string{"b.conf: invalid config", "sub/c.conf: invalid config"}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	// The walk continued past the failures, but not into skipped directories.
+	if !slices.Contains(visited, "sub/d.conf") {
+		t.Errorf("visited = %q, want the walk to continue after a failure", visited)
+	}
+	for _, name := range visited {
+		if path.Dir(name) == "vendor" || strings.HasPrefix(name, "zzz/stop/") {
+			t.Errorf("visited %q inside a skipped directory", name)
+		}
+	}
+}
+
+func TestWalkDirFuncWalkErrors(t *testing.T) {
+	errDenied := errors.New("permission denied")
+	c := rxmerr.NewCollector()
+	walk := c.WalkDirFunc(func(string, fs.DirEntry, error) error { return errDenied })
+
+	if got := walk("x", nil, nil); got != nil {
+		t.Errorf("wrapped func returned %v, want nil", got)
+	}
+	if !errors.Is(c.Err(), errDenied) {
+		t.Errorf("Err() = %v, want %v", c.Err(), errDenied)
+	}
+}
+
+func TestSwallow(t *testing.T) {
+	c := rxmerr.NewCollector()
+	validate := rxmerr.Swallow(c, func(n int) error {
+		if n%2 != 0 {
+			return fmt.Errorf("record %d: odd", n)
+		}
+		return nil
+	})
+
+	for n := range 5 {
+		if err := validate(n); err != nil {
+			t.Fatalf("validate(%d) = %v, want nil", n, err)
+		}
+	}
+
+	want := []string{"record 1: odd", "record 3: odd"}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+}
+
+// closer records whether Close was called and returns err.
+type closer struct {
+	err    error
+	closed bool
+}
+
+func (c *closer) Close() error {
+	c.closed = true
+	return c.err
+}
+
+func TestAppendCloseAll(t *testing.T) {
+	errReset := errors.New("connection reset")
+	closers := []*closer{{}, {err: errReset}, {}, {err: io.ErrClosedPipe}}
+
+	c := rxmerr.NewCollector()
+	c.AppendCloseAll(closers[0], nil, closers[1], closers[2], nil, closers[3])
+
+	for i, cl := range closers {
+		if !cl.closed {
+			t.Errorf("closers[%d] was not closed", i)
+		}
+	}
+	want := []string{"closer 2: connection reset", "closer 5: io: read/write on closed pipe"}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	err := c.Err()
+	if !errors.Is(err, errReset) || !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("Err() = %v, close errors not reachable via errors.Is", err)
+	}
+}
+
+func TestAppendCloseAllNoFailures(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.AppendCloseAll()
+	c.AppendCloseAll(nil, &closer{}, nil)
+	if err := c.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
+
+func TestCollectingWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		want   []string
+	}{
+		{name: "lines", writes: []string{"a\nb\n"}, want: []string{"a", "b"}},
+		{name: "split lines", writes: []string{"conn", "ection ref", "used\nti", "meout\n"}, want: []string{"connection refused", "timeout"}},
+		{name: "CRLF", writes: []string{"a\r\nb\r", "\n"}, want: []string{"a", "b"}},
+		{name: "empty lines", writes: []string{"\n\r\na\n\n", "\n"}, want: []string{"a"}},
+		{name: "unterminated tail", writes: []string{"a\nb"}, want: []string{"a", "b"}},
+		{name: "nothing written"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := rxmerr.NewCollectingWriter()
+			for _, s := range tt.writes {
+				if n, err := w.Write([]byte(s)); n != len(s) || err != nil {
+					t.Fatalf("Write(%q) = (%d, %v), want (%d, nil)", s, n, err, len(s))
+				}
+			}
+			w.Flush()
+			if got := w.Messages(); !slices.Equal(got, tt.want) {
+				t.Errorf("Messages() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectingWriterFlush(t *testing.T) {
+	var w rxmerr.CollectingWriter
+	io.WriteString(&w, "a\npartial")
+	if got, want := w.Messages(), []string{"a"}; !slices.Equal(got, want) {
+		t.Errorf("Messages() before Flush = %q, want %q", got, want)
+	}
+
+	w.Flush()
+	w.Flush()
+	if got, want := w.Messages(), []string{"a", "partial"}; !slices.Equal(got, want) {
+		t.Errorf("Messages() after Flush = %q, want %q", got, want)
+	}
+}
+
+func TestCollectingWriterOptions(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	w := rxmerr.NewCollectingWriter(
+		rxmerr.WithClock(fakeClock(&now)),
+		rxmerr.TimestampPrefix(time.TimeOnly, nil),
+	)
+	io.WriteString(w, "a\n")
+	now = now.Add(time.Second)
+	io.WriteString(w, "b\n")
+
+	if got, want := w.Messages(), []string{"[08:00:00] a", "[08:00:01] b"}; !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,271 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"dirpx.dev/rxmerr"
+)
+
+// backends lists the aggregation backends every helper must treat
+// identically.
+var backends = []struct {
+	name    string
+	combine func(...error) error
+	opts    []rxmerr.Option
+}{
+	{"multierr", rxmerr.Combine, nil},
+	{"join", rxmerr.JoinCombine, []rxmerr.Option{rxmerr.WithJoinBackend()}},
+}
+
+// TestHelpersOnBothBackends runs the helpers of the package against
+// aggregates built by each backend and requires the same results.
+func TestHelpersOnBothBackends(t *testing.T) {
+	errA := errors.New("a")
+	errTimeout := fmt.Errorf("dial 10.0.0.7: %w", context.DeadlineExceeded)
+	errUsage := fmt.Errorf("%w: unknown flag", rxmerr.ErrUsage)
+	errRetry := rxmerr.WithRetryAfter(errors.New("rate limited"), time.Second)
+
+	tests := []struct {
+		name string
+		run  func(combine func(...error) error) any
+		want any
+	}{
+		{
+			name: "Errors",
+			run: func(combine func(...error) error) any {
+				return rxmerr.Errors(combine(errA, io.EOF))
+			},
+			want: []error{errA, io.EOF},
+		},
+		{
+			name: "Errors nested",
+			run: func(combine func(...error) error) any {
+				return rxmerr.Errors(combine(combine(errA, io.EOF), errUsage))
+			},
+			want: []error{errA, io.EOF, errUsage},
+		},
+		{
+			name: "Errors nil",
+			run: func(combine func(...error) error) any {
+				return rxmerr.Errors(combine(nil, nil))
+			},
+			want: []error(nil),
+		},
+		{
+			name: "Messages",
+			run: func(combine func(...error) error) any {
+				return rxmerr.Messages(combine(errA, io.EOF))
+			},
+			want: []string{"a", "EOF"},
+		},
+		{
+			name: "Format",
+			run: func(combine func(...error) error) any {
+				return rxmerr.Format(combine(errA, io.EOF))
+			},
+			want: "2 errors occurred:\n  [0] a\n  [1] EOF",
+		},
+		{
+			name: "FormatBullet",
+			run: func(combine func(...error) error) any {
+				return rxmerr.FormatBullet(combine(errA, io.EOF))
+			},
+			want: "- a\n- EOF",
+		},
+		{
+			name: "ErrorsMap",
+			run: func(combine func(...error) error) any {
+				return rxmerr.ErrorsMap(combine(errA, io.EOF))
+			},
+			want: map[int]string{0: "a", 1: "EOF"},
+		},
+		{
+			name: "errors.Is",
+			run: func(combine func(...error) error) any {
+				err := combine(errA, errTimeout)
+				return []bool{errors.Is(err, errA), errors.Is(err, context.DeadlineExceeded), errors.Is(err, io.EOF)}
+			},
+			want: []bool{true, true, false},
+		},
+		{
+			name: "errors.As",
+			run: func(combine func(...error) error) any {
+				var hint rxmerr.RetryAfterHint
+				ok := errors.As(combine(errA, errRetry), &hint)
+				return ok && hint.RetryAfter() == time.Second
+			},
+			want: true,
+		},
+		{
+			name: "Dominant",
+			run: func(combine func(...error) error) any {
+				dom, n, majority := rxmerr.Dominant(combine(io.EOF, errA, io.EOF))
+				return []any{dom, n, majority}
+			},
+			want: []any{io.EOF, 2, true},
+		},
+		{
+			name: "Cluster",
+			run: func(combine func(...error) error) any {
+				var counts []int
+				for _, cl := range rxmerr.Cluster(combine(io.EOF, errA, io.EOF), nil) {
+					counts = append(counts, cl.Count)
+				}
+				return counts
+			},
+			want: []int{2, 1},
+		},
+		{
+			name: "Summarize",
+			run: func(combine func(...error) error) any {
+				rules := []rxmerr.SummaryRule{{Label: "timeout", Target: context.DeadlineExceeded}}
+				return rxmerr.Summarize(combine(errTimeout, errA, errTimeout), rules)
+			},
+			want: "2 timeouts, 1 other",
+		},
+		{
+			name: "MatchesExactly",
+			run: func(combine func(...error) error) any {
+				err := combine(errTimeout, errA)
+				return []bool{
+					rxmerr.MatchesExactly(err, errA, context.DeadlineExceeded),
+					rxmerr.MatchesExactly(err, errA),
+				}
+			},
+			want: []bool{true, false},
+		},
+		{
+			name: "ContainsExactly",
+			run: func(combine func(...error) error) any {
+				return rxmerr.ContainsExactly(combine(io.EOF, errA), "a", "EOF")
+			},
+			want: true,
+		},
+		{
+			name: "EqualIgnoring",
+			run: func(combine func(...error) error) any {
+				return rxmerr.EqualIgnoring(combine(errA, io.EOF), rxmerr.Combine(io.EOF, errA), nil)
+			},
+			want: true,
+		},
+		{
+			name: "Translate",
+			run: func(combine func(...error) error) any {
+				err := rxmerr.Translate(combine(io.EOF, errA), []rxmerr.Translation{{Target: io.EOF}})
+				return rxmerr.Messages(err)
+			},
+			want: []string{"a"},
+		},
+		{
+			name: "ExitCode",
+			run: func(combine func(...error) error) any {
+				return rxmerr.ExitCode(combine(errA, errUsage), nil)
+			},
+			want: 2,
+		},
+		{
+			name: "MaxRetryAfter",
+			run: func(combine func(...error) error) any {
+				d, ok := rxmerr.MaxRetryAfter(combine(errA, errRetry))
+				return []any{d, ok}
+			},
+			want: []any{time.Second, true},
+		},
+		{
+			name: "header round trip",
+			run: func(combine func(...error) error) any {
+				h, err := rxmerr.EncodeHeader(combine(errA, io.EOF))
+				if err != nil {
+					return err
+				}
+				return rxmerr.Messages(rxmerr.DecodeHeader(h))
+			},
+			want: []string{"a", "EOF"},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, b := range backends {
+			t.Run(tt.name+"/"+b.name, func(t *testing.T) {
+				if got := tt.run(b.combine); !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("got %#v, want %#v", got, tt.want)
+				}
+			})
+		}
+	}
+}
+
+// TestCollectorOnBothBackends checks that the collector behaves identically
+// on either backend, apart from the shape of the aggregate returned by Err.
+func TestCollectorOnBothBackends(t *testing.T) {
+	errA := errors.New("a")
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			c := rxmerr.NewCollector(b.opts...)
+			c.Append(errA, nil, io.EOF)
+			c.Append(b.combine(io.ErrClosedPipe, errA))
+
+			if got := c.Len(); got != 3 {
+				t.Errorf("Len() = %d, want 3", got)
+			}
+			want := []string{"a", "EOF", b.combine(io.ErrClosedPipe, errA).Error()}
+			if got := c.Messages(); !slices.Equal(got, want) {
+				t.Errorf("Messages() = %q, want %q", got, want)
+			}
+			err := c.Err()
+			if got, want := rxmerr.Errors(err), []error{errA, io.EOF, io.ErrClosedPipe, errA}; !slices.Equal(got, want) {
+				t.Errorf("Errors(Err()) = %v, want %v", got, want)
+			}
+			if got, want := err.Error(), b.combine(errA, io.EOF, io.ErrClosedPipe, errA).Error(); got != want {
+				t.Errorf("Err() = %q, want %q", got, want)
+			}
+			if !c.Contains(io.ErrClosedPipe) {
+				t.Error("Contains(io.ErrClosedPipe) = false")
+			}
+
+			c.Reset()
+			if err := c.Err(); err != nil {
+				t.Errorf("Err() after Reset = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestJoinBackendShape(t *testing.T) {
+	c := rxmerr.NewCollector(rxmerr.WithJoinBackend())
+	c.Append(errors.New("a"), io.EOF)
+
+	err := c.Err()
+	if _, ok := err.(interface{ Unwrap() []error }); !ok {
+		t.Fatalf("Err() = %T, want an Unwrap() []error aggregate", err)
+	}
+	if got, want := err.Error(), "a\nEOF"; got != want {
+		t.Errorf("Err() = %q, want %q", got, want)
+	}
+	if _, ok := rxmerr.AsMultiError(err); ok {
+		t.Errorf("AsMultiError(%T) = true, want a standard library aggregate", err)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+// batchErrs returns n distinct errors for the append benchmarks.
+func batchErrs(n int) []error {
+	errs := make([]error, n)
+	for i := range errs {
+		errs[i] = fmt.Errorf("error %d", i)
+	}
+	return errs
+}
+
+func TestAppendBatchMatchesAppend(t *testing.T) {
+	errs := append(batchErrs(5), nil, errors.New("last"))
+
+	var looped rxmerr.Collector
+	for _, err := range errs {
+		looped.Append(err)
+	}
+	var batched rxmerr.Collector
+	batched.AppendBatch(errs)
+
+	if batched.Len() != looped.Len() {
+		t.Errorf("Len() = %d, want %d", batched.Len(), looped.Len())
+	}
+	if !rxmerr.EqualErrors(batched.Err(), looped.Err()) {
+		t.Errorf("Err() = %v, want %v", batched.Err(), looped.Err())
+	}
+}
+
+func BenchmarkAppendBatch(b *testing.B) {
+	errs := batchErrs(100)
+	b.ReportAllocs()
+	for b.Loop() {
+		var c rxmerr.Collector
+		c.AppendBatch(errs)
+		_ = c.Err()
+	}
+}
+
+func BenchmarkAppendLoop(b *testing.B) {
+	errs := batchErrs(100)
+	b.ReportAllocs()
+	for b.Loop() {
+		var c rxmerr.Collector
+		for _, err := range errs {
+			c.Append(err)
+		}
+		_ = c.Err()
+	}
+}
@@ -0,0 +1,93 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+// OtherCategory is the bucket used by Collector.Categorize for errors that
+// match none of the given categories.
+const OtherCategory = "__other__"
+
+// ErrorCategory is a named class of errors used by Collector.Categorize.
+type ErrorCategory struct {
+	// Name is the key under which matching errors are reported.
+	Name string
+
+	// Match reports whether an error belongs to the category.
+	Match func(error) bool
+}
+
+// Categorize splits the collected errors into named buckets.
+//
+// Each collected error (in the order returned by Errors) is placed into the
+// first category whose Match reports true; errors that match no category are
+// placed into the OtherCategory bucket. The resulting map contains only
+// non-empty buckets and is nil if no errors were collected:
+//
+//	buckets := c.Categorize(
+//	    rxmerr.ErrorCategory{Name: "retryable", Match: isRetryable},
+//	    rxmerr.ErrorCategory{Name: "user", Match: isUserError},
+//	)
+//	for _, err := range buckets[rxmerr.OtherCategory] {
+//	    ...
+//	}
+//
+// Categories sharing a name are merged into one bucket.
+func (c *Collector) Categorize(cats ...ErrorCategory) map[string][]error {
+	errs := c.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+
+	buckets := make(map[string][]error)
+	for _, err := range errs {
+		name := OtherCategory
+		for _, cat := range cats {
+			if cat.Match(err) {
+				name = cat.Name
+				break
+			}
+		}
+		buckets[name] = append(buckets[name], err)
+	}
+	return buckets
+}
+
+// SplitFatal partitions the collected errors with isFatal and returns the
+// errors for which it reports true and the rest, each recombined with
+// Combine:
+//
+//	fatal, nonFatal := c.SplitFatal(func(err error) bool {
+//	    return errors.Is(err, ErrUnauthorized)
+//	})
+//	if nonFatal != nil {
+//	    logger.Warn("request degraded", "err", nonFatal)
+//	}
+//	return fatal
+//
+// Within each half, errors keep the order returned by Errors. Either result
+// is nil if no collected error falls into it; an empty collector yields two
+// nils.
+func (c *Collector) SplitFatal(isFatal func(error) bool) (fatal error, nonFatal error) {
+	var fatals, others []error
+	for _, err := range c.Errors() {
+		if isFatal(err) {
+			fatals = append(fatals, err)
+		} else {
+			others = append(others, err)
+		}
+	}
+	return Combine(fatals...), Combine(others...)
+}
@@ -0,0 +1,90 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestCategorize(t *testing.T) {
+	errA := errors.New("a")
+	c := rxmerr.NewCollector()
+	if got := c.Categorize(); got != nil {
+		t.Errorf("Categorize() on empty collector = %v, want nil", got)
+	}
+
+	c.Append(io.EOF, errA, io.ErrUnexpectedEOF, io.EOF)
+	got := c.Categorize(
+		rxmerr.ErrorCategory{Name: "eof", Match: func(err error) bool { return err == io.EOF }},
+		rxmerr.ErrorCategory{Name: "unused", Match: func(error) bool { return false }},
+		rxmerr.ErrorCategory{Name: "eof", Match: func(err error) bool { return err == io.ErrUnexpectedEOF }},
+	)
+
+	want := map[string][]error{
+		"eof":                {io.EOF, io.ErrUnexpectedEOF, io.EOF},
+		rxmerr.OtherCategory: {errA},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Categorize() = %v, want %v", got, want)
+	}
+	for name, errs := range want {
+		if !slices.Equal(got[name], errs) {
+			t.Errorf("Categorize()[%q] = %v, want %v", name, got[name], errs)
+		}
+	}
+}
+
+func TestSplitFatal(t *testing.T) {
+	errDenied := errors.New("permission denied")
+	isFatal := func(err error) bool { return errors.Is(err, errDenied) }
+	errA := errors.New("a")
+
+	tests := []struct {
+		name         string
+		errs         []error
+		wantFatal    []error
+		wantNonFatal []error
+	}{
+		{name: "empty"},
+		{name: "mixed", errs: []error{errA, errDenied, io.EOF, errDenied}, wantFatal: []error{errDenied, errDenied}, wantNonFatal: []error{errA, io.EOF}},
+		{name: "fatal only", errs: []error{errDenied}, wantFatal: []error{errDenied}},
+		{name: "non-fatal only", errs: []error{errA, io.EOF}, wantNonFatal: []error{errA, io.EOF}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := rxmerr.NewCollector()
+			c.Append(tt.errs...)
+			fatal, nonFatal := c.SplitFatal(isFatal)
+
+			if got := rxmerr.Errors(fatal); !slices.Equal(got, tt.wantFatal) {
+				t.Errorf("SplitFatal() fatal = %v, want %v", got, tt.wantFatal)
+			}
+			if got := rxmerr.Errors(nonFatal); !slices.Equal(got, tt.wantNonFatal) {
+				t.Errorf("SplitFatal() nonFatal = %v, want %v", got, tt.wantNonFatal)
+			}
+			if (fatal == nil) != (len(tt.wantFatal) == 0) || (nonFatal == nil) != (len(tt.wantNonFatal) == 0) {
+				t.Errorf("SplitFatal() = (%v, %v), want nil for empty halves", fatal, nonFatal)
+			}
+		})
+	}
+}
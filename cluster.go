@@ -0,0 +1,246 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// Clustered is a group of constituents that share the same cluster key.
+type Clustered struct {
+	// Key is the value returned by the keyer for every member of the cluster.
+	Key string
+
+	// Representative is the first member of the cluster, in Errors order.
+	Representative error
+
+	// Count is the number of members in the cluster.
+	Count int
+
+	// Members lists all constituents of the cluster, in Errors order.
+	Members []error
+}
+
+// Cluster groups the constituents of err by the string returned by key.
+//
+// Constituents are obtained via Errors. Clusters are returned in the order in
+// which their first member appears, and members keep their relative order.
+// If key is nil, KeyByTemplate is used, which groups messages that differ
+// only in variable parts such as addresses or identifiers:
+//
+//	for _, cl := range rxmerr.Cluster(err, nil) {
+//	    log.Printf("%d× %v", cl.Count, cl.Representative)
+//	}
+//
+// If err is nil, Cluster returns nil.
+func Cluster(err error, key func(error) string) []Clustered {
+	errs := Errors(err)
+	if len(errs) == 0 {
+		return nil
+	}
+	if key == nil {
+		key = KeyByTemplate
+	}
+
+	var clusters []Clustered
+	index := make(map[string]int)
+	for _, e := range errs {
+		k := key(e)
+		i, ok := index[k]
+		if !ok {
+			i = len(clusters)
+			index[k] = i
+			clusters = append(clusters, Clustered{Key: k, Representative: e})
+		}
+		clusters[i].Count++
+		clusters[i].Members = append(clusters[i].Members, e)
+	}
+	return clusters
+}
+
+var (
+	uuidPattern = regexp.MustCompile(`[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}`)
+	ipv6Pattern = regexp.MustCompile(`[0-9A-Fa-f]*:[0-9A-Fa-f:.]*:[0-9A-Fa-f.]*(?:%[0-9A-Za-z_.-]+)?`)
+	ipv4Pattern = regexp.MustCompile(`\b\d{1,3}(?:\.\d{1,3}){3}\b`)
+	hexPattern  = regexp.MustCompile(`\b(?:0[xX])?[0-9A-Fa-f]{8,}\b`)
+	numPattern  = regexp.MustCompile(`\d+`)
+)
+
+// KeyByTemplate returns the message of err with its variable parts masked, so
+// that near-identical messages map to the same key.
+//
+// Masking is applied in the following order:
+//
+//   - UUIDs are replaced with "<uuid>";
+//   - IPv6 addresses (including bracketed, zoned and IPv4-mapped forms)
+//     are replaced with "<ip>";
+//   - IPv4 addresses are replaced with "<ip>";
+//   - hexadecimal runs of at least 8 digits containing a decimal digit (trace
+//     IDs, hashes, pointers) are replaced with "<hex>";
+//   - remaining decimal numbers, including ports, are replaced with "<n>".
+//
+// For example, "dial tcp 10.0.0.7:443: i/o timeout" and
+// "dial tcp [2001:db8::1]:8443: i/o timeout" map to
+// "dial tcp <ip>:<n>: i/o timeout" and "dial tcp [<ip>]:<n>: i/o timeout"
+// respectively. The heuristic is deliberately conservative: text that merely
+// resembles an address but does not parse as one is left to the later steps.
+//
+// If err is nil, KeyByTemplate returns an empty string.
+func KeyByTemplate(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	s := uuidPattern.ReplaceAllString(err.Error(), "<uuid>")
+	s = ipv6Pattern.ReplaceAllStringFunc(s, maskIPv6)
+	s = ipv4Pattern.ReplaceAllString(s, "<ip>")
+	s = hexPattern.ReplaceAllStringFunc(s, maskHex)
+	return numPattern.ReplaceAllString(s, "<n>")
+}
+
+// maskIPv6 replaces an IPv6 candidate with "<ip>" if it parses as an IPv6
+// address. Trailing separators matched by the candidate pattern (for example
+// the colon in "fe80::1: refused") are preserved.
+func maskIPv6(candidate string) string {
+	addr := strings.TrimRight(candidate, ":.")
+	host := addr
+	if i := strings.IndexByte(host, '%'); i >= 0 {
+		host = host[:i]
+	}
+	if net.ParseIP(host) == nil {
+		return candidate
+	}
+	return "<ip>" + candidate[len(addr):]
+}
+
+// maskHex replaces a hexadecimal run with "<hex>" if it contains at least one
+// decimal digit, which keeps plain words such as "acceptable" intact.
+func maskHex(run string) string {
+	if strings.IndexAny(run, "0123456789") < 0 {
+		return run
+	}
+	return "<hex>"
+}
+
+// FormatClustered renders err as a compact, human-readable summary in which
+// near-identical constituents are collapsed.
+//
+// Constituents are clustered with KeyByTemplate. A cluster with a single
+// member is rendered as that member's message. In larger clusters, the
+// variable part of a message is the span of the representative that was
+// masked by KeyByTemplate, such as "10.0.0.7:443". When it contains an
+// address and is followed by ": " and a cause, as in the messages of package
+// net, the cluster is rendered as the cause, the number of distinct
+// addresses, and an example address:
+//
+//	i/o timeout to 50 hosts (e.g. 10.0.0.7:443)
+//
+// If some addresses occur several times, the number of occurrences is added,
+// as in "i/o timeout to 2 hosts (5 occurrences, e.g. 10.0.0.7:443)". Other
+// clusters are rendered as their template followed by the member count and
+// an example of the variable part:
+//
+//	job <n> failed (3 occurrences, e.g. 17)
+//
+// Clusters are separated by "; ", matching the compact multierr format. If err
+// is nil, FormatClustered returns an empty string.
+func FormatClustered(err error) string {
+	var b strings.Builder
+	for i, cl := range Cluster(err, KeyByTemplate) {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		if cl.Count == 1 {
+			b.WriteString(cl.Representative.Error())
+			continue
+		}
+		writeCluster(&b, cl)
+	}
+	return b.String()
+}
+
+// placeholderPattern matches the placeholders inserted by KeyByTemplate.
+var placeholderPattern = regexp.MustCompile(`<(?:uuid|ip|hex|n)>`)
+
+// writeCluster writes the rendering of cl, which has several members, as
+// described by FormatClustered.
+func writeCluster(b *strings.Builder, cl Clustered) {
+	example, pattern, after, ok := variablePart(cl.Representative.Error(), cl.Key)
+	if !ok {
+		fmt.Fprintf(b, "%s (%d occurrences)", cl.Key, cl.Count)
+		return
+	}
+	cause, isNet := strings.CutPrefix(after, ": ")
+	if !isNet || !strings.Contains(pattern, "<ip>") {
+		fmt.Fprintf(b, "%s (%d occurrences, e.g. %s)", cl.Key, cl.Count, example)
+		return
+	}
+
+	hosts := make(map[string]bool)
+	for _, m := range cl.Members {
+		if v, _, _, ok := variablePart(m.Error(), cl.Key); ok {
+			hosts[v] = true
+		}
+	}
+	noun := "hosts"
+	if len(hosts) == 1 {
+		noun = "host"
+	}
+	if len(hosts) == cl.Count {
+		fmt.Fprintf(b, "%s to %d %s (e.g. %s)", cause, len(hosts), noun, example)
+	} else {
+		fmt.Fprintf(b, "%s to %d %s (%d occurrences, e.g. %s)", cause, len(hosts), noun, cl.Count, example)
+	}
+}
+
+// variablePart matches msg against template, its KeyByTemplate key, and
+// returns the span of msg covered by the placeholders of template, the
+// corresponding part of template, and the template text that follows it. A
+// bracket opening the span, as in "[2001:db8::1]:443", is included. It
+// reports false if template has no placeholder or does not match msg.
+func variablePart(msg, template string) (span, pattern, after string, ok bool) {
+	locs := placeholderPattern.FindAllStringIndex(template, -1)
+	if len(locs) == 0 {
+		return "", "", "", false
+	}
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	prev := 0
+	for _, loc := range locs {
+		expr.WriteString(regexp.QuoteMeta(template[prev:loc[0]]))
+		expr.WriteString("(.+?)")
+		prev = loc[1]
+	}
+	expr.WriteString(regexp.QuoteMeta(template[prev:]))
+	expr.WriteString("$")
+
+	m := regexp.MustCompile(expr.String()).FindStringSubmatchIndex(msg)
+	if m == nil {
+		return "", "", "", false
+	}
+	start, end := m[2], m[len(m)-1]
+	first := locs[0][0]
+	if strings.HasSuffix(template[:first], "[") {
+		start--
+		first--
+	}
+	return msg[start:end], template[first:locs[len(locs)-1][1]], template[locs[len(locs)-1][1]:], true
+}
@@ -0,0 +1,162 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestKeyByTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want string
+	}{
+		{"ipv4 with port", "dial tcp 10.0.0.7:443: i/o timeout", "dial tcp <ip>:<n>: i/o timeout"},
+		{"ipv4 without port", "no route to 192.168.1.254", "no route to <ip>"},
+		{"bracketed ipv6 with port", "dial tcp [2001:db8::1]:8443: i/o timeout", "dial tcp [<ip>]:<n>: i/o timeout"},
+		{"bare ipv6", "connect fe80::1: connection refused", "connect <ip>: connection refused"},
+		{"zoned ipv6", "dial fe80::1%eth0 failed", "dial <ip> failed"},
+		{"ipv4-mapped ipv6", "peer ::ffff:10.0.0.1 reset", "peer <ip> reset"},
+		{"full ipv6", "host 2001:0db8:85a3:0000:0000:8a2e:0370:7334 down", "host <ip> down"},
+		{"uuid", "order 123e4567-e89b-12d3-a456-426614174000 not found", "order <uuid> not found"},
+		{"upper-case uuid", "id 123E4567-E89B-12D3-A456-426614174000", "id <uuid>"},
+		{"hex trace id", "trace 4bf92f3577b34da6a3ce929d0e0e4736 failed", "trace <hex> failed"},
+		{"pointer", "bad object 0xc000012345", "bad object <hex>"},
+		{"hex-like word kept", "unacceptable deadbeefcafe value", "unacceptable deadbeefcafe value"},
+		{"plain numbers", "retry 3 of 10 failed", "retry <n> of <n> failed"},
+		{"time of day is not an address", "at 12:30:45 disk full", "at <n>:<n>:<n> disk full"},
+		{"no variable parts", "permission denied", "permission denied"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rxmerr.KeyByTemplate(errors.New(tt.msg)); got != tt.want {
+				t.Errorf("KeyByTemplate(%q) = %q, want %q", tt.msg, got, tt.want)
+			}
+		})
+	}
+
+	if got := rxmerr.KeyByTemplate(nil); got != "" {
+		t.Errorf("KeyByTemplate(nil) = %q, want empty", got)
+	}
+}
+
+func TestCluster(t *testing.T) {
+	var errs []error
+	for i := 1; i <= 3; i++ {
+		errs = append(errs, fmt.Errorf("dial tcp 10.0.0.%d:443: i/o timeout", i))
+	}
+	denied := errors.New("permission denied")
+	errs = append(errs, denied, errors.New("dial tcp 10.0.0.9:80: i/o timeout"))
+
+	clusters := rxmerr.Cluster(rxmerr.Combine(errs...), nil)
+	if len(clusters) != 2 {
+		t.Fatalf("len(Cluster()) = %d, want 2: %+v", len(clusters), clusters)
+	}
+
+	timeouts := clusters[0]
+	if timeouts.Key != "dial tcp <ip>:<n>: i/o timeout" || timeouts.Count != 4 || len(timeouts.Members) != 4 {
+		t.Errorf("first cluster = %q with %d members, want the 4 timeouts", timeouts.Key, timeouts.Count)
+	}
+	if timeouts.Representative != errs[0] {
+		t.Errorf("Representative = %v, want the first timeout", timeouts.Representative)
+	}
+	if timeouts.Members[3] != errs[4] {
+		t.Errorf("Members[3] = %v, want %v", timeouts.Members[3], errs[4])
+	}
+
+	if clusters[1].Representative != denied || clusters[1].Count != 1 {
+		t.Errorf("second cluster = %+v, want the single permission error", clusters[1])
+	}
+}
+
+func TestClusterCustomKey(t *testing.T) {
+	err := rxmerr.Combine(errors.New("a1"), errors.New("b2"), errors.New("a3"))
+	byFirstByte := func(err error) string { return err.Error()[:1] }
+
+	clusters := rxmerr.Cluster(err, byFirstByte)
+	if len(clusters) != 2 || clusters[0].Key != "a" || clusters[0].Count != 2 || clusters[1].Key != "b" {
+		t.Errorf("Cluster() = %+v, want clusters a×2 and b×1", clusters)
+	}
+	if rxmerr.Cluster(nil, byFirstByte) != nil {
+		t.Error("Cluster(nil) != nil")
+	}
+}
+
+func TestFormatClustered(t *testing.T) {
+	dial := func(hosts ...string) []error {
+		var errs []error
+		for _, host := range hosts {
+			errs = append(errs, fmt.Errorf("dial tcp %s: i/o timeout", host))
+		}
+		return errs
+	}
+
+	tests := []struct {
+		name string
+		errs []error
+		want string
+	}{
+		{
+			name: "hosts",
+			errs: append(dial("10.0.0.7:443", "10.0.0.8:443", "[2001:db8::1]:443"), errors.New("permission denied")),
+			want: "i/o timeout to 2 hosts (e.g. 10.0.0.7:443); dial tcp [2001:db8::1]:443: i/o timeout; permission denied",
+		},
+		{
+			name: "bracketed IPv6",
+			errs: dial("[2001:db8::1]:443", "[2001:db8::2]:8443"),
+			want: "i/o timeout to 2 hosts (e.g. [2001:db8::1]:443)",
+		},
+		{
+			name: "repeated hosts",
+			errs: dial("10.0.0.7:443", "10.0.0.8:443", "10.0.0.7:443"),
+			want: "i/o timeout to 2 hosts (3 occurrences, e.g. 10.0.0.7:443)",
+		},
+		{
+			name: "single host",
+			errs: dial("10.0.0.7:443", "10.0.0.7:443"),
+			want: "i/o timeout to 1 host (2 occurrences, e.g. 10.0.0.7:443)",
+		},
+		{
+			name: "no address",
+			errs: []error{errors.New("job 17 failed"), errors.New("job 23 failed"), errors.New("job 4 failed")},
+			want: "job <n> failed (3 occurrences, e.g. 17)",
+		},
+		{
+			name: "identical",
+			errs: []error{errors.New("permission denied"), errors.New("permission denied")},
+			want: "permission denied (2 occurrences)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rxmerr.FormatClustered(rxmerr.Combine(tt.errs...)); got != tt.want {
+				t.Errorf("FormatClustered() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if got := rxmerr.FormatClustered(nil); got != "" {
+		t.Errorf("FormatClustered(nil) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"fmt"
+	"time"
+)
+
+// coalesceConfig holds the settings of WithCoalesce and CollapseConsecutive.
+type coalesceConfig struct {
+	window time.Duration
+	timed  bool             // false for untimed merging, see CollapseConsecutive
+	clock  func() time.Time // nil to use the collector's clock
+}
+
+// coalesceRun tracks the run of identical messages at the end of the stored
+// errors.
+type coalesceRun struct {
+	msg     string    // message shared by the run
+	first   error     // first error of the run, as it was stored
+	start   time.Time // time of the first occurrence
+	last    time.Time // time of the latest occurrence
+	repeats int       // number of occurrences after the first
+}
+
+// WithCoalesce returns an option that merges bursts of identical errors into
+// a single stored entry.
+//
+// When an appended error has the same message as the previously appended one
+// and arrives within window of it, it is not stored separately. Instead, the
+// stored entry is replaced by one whose message records the repetition:
+//
+//	upstream unavailable (repeated 4999 times over 1.8s)
+//
+// The replacement unwraps to the first error of the run, so errors.Is and
+// errors.As keep working. A different message in between ends the run; the
+// next occurrence starts a new one. A non-positive window merges consecutive
+// repeats regardless of the time between them.
+//
+// Len keeps counting every occurrence, while Stored counts physical entries.
+// Time is read from clock; if clock is nil, the collector's clock is used,
+// see WithClock.
+func WithCoalesce(window time.Duration, clock func() time.Time) Option {
+	return func(c *Collector) {
+		c.coalesce = &coalesceConfig{window: window, timed: true, clock: clock}
+	}
+}
+
+// CollapseConsecutive returns an option that collapses runs of consecutive
+// errors with identical messages into a single stored entry, like uniq(1).
+//
+// When an appended error has the same message as the previously appended
+// one, it is not stored separately; instead, the stored entry is replaced by
+// one whose message records the repetition:
+//
+//	upstream unavailable (repeated 3 times)
+//
+// Only consecutive repeats are collapsed: a different message in between ends
+// the run, and a later occurrence starts a new one. Otherwise the option
+// behaves like WithCoalesce with an unlimited window, except that no time is
+// measured or rendered.
+func CollapseConsecutive() Option {
+	return func(c *Collector) {
+		c.coalesce = &coalesceConfig{}
+	}
+}
+
+// coalesceInto merges err into the last stored entry if it continues the
+// current run, reporting whether it did. Otherwise it starts a new run with
+// err, which the caller is expected to store.
+func (c *Collector) coalesceInto(err error) bool {
+	var now time.Time
+	if c.coalesce.timed {
+		now = c.timeFrom(c.coalesce.clock)
+	}
+	msg := err.Error()
+
+	r := &c.run
+	if r.first != nil && msg == r.msg && (c.coalesce.window <= 0 || now.Sub(r.last) <= c.coalesce.window) {
+		r.repeats++
+		r.last = now
+		c.errs[len(c.errs)-1] = &repeatedError{err: r.first, repeats: r.repeats, span: r.last.Sub(r.start), timed: c.coalesce.timed}
+		c.err = nil
+		return true
+	}
+
+	*r = coalesceRun{msg: msg, first: err, start: now, last: now}
+	return false
+}
+
+// repeatedError is the stored form of a coalesced run of identical errors.
+type repeatedError struct {
+	err     error
+	repeats int
+	span    time.Duration
+	timed   bool // whether span was measured and is rendered
+}
+
+func (e *repeatedError) Error() string {
+	if !e.timed {
+		return fmt.Sprintf("%s (repeated %d times)", e.err.Error(), e.repeats)
+	}
+	return fmt.Sprintf("%s (repeated %d times over %s)", e.err.Error(), e.repeats, e.span.Round(time.Millisecond))
+}
+
+// Unwrap returns the first error of the run.
+func (e *repeatedError) Unwrap() error {
+	return e.err
+}
+
+// Format implements fmt.Formatter: %+v writes Error() followed by the detail
+// of the first error of the run, %q quotes Error(), and other verbs write
+// Error(). See formatSummary.
+func (e *repeatedError) Format(f fmt.State, verb rune) {
+	formatSummary(f, verb, e, e.err)
+}
@@ -0,0 +1,150 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestWithCoalesce(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.WithCoalesce(time.Second, fakeClock(&now)))
+
+	errBlip := errors.New("upstream unavailable")
+	c.Append(errBlip)
+	for range 4 {
+		now = now.Add(450 * time.Millisecond)
+		c.Append(errors.New("upstream unavailable"))
+	}
+
+	if got := c.Len(); got != 5 {
+		t.Errorf("Len() = %d, want 5", got)
+	}
+	if got := c.Stored(); got != 1 {
+		t.Errorf("Stored() = %d, want 1", got)
+	}
+	want := []string{"upstream unavailable (repeated 4 times over 1.8s)"}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	if !errors.Is(c.Err(), errBlip) {
+		t.Errorf("Err() = %v, does not unwrap to the first error of the run", c.Err())
+	}
+}
+
+func TestWithCoalesceOutOfWindow(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.WithCoalesce(time.Second, fakeClock(&now)))
+
+	c.Append(io.EOF)
+	now = now.Add(time.Second)
+	c.Append(io.EOF) // exactly at the window edge: merged
+	now = now.Add(time.Second + time.Millisecond)
+	c.Append(io.EOF) // past the window: starts a new run
+
+	if got := c.Len(); got != 3 {
+		t.Errorf("Len() = %d, want 3", got)
+	}
+	want := []string{"EOF (repeated 1 times over 1s)", "EOF"}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+}
+
+func TestWithCoalesceInterleaved(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.WithCoalesce(time.Minute, fakeClock(&now)))
+
+	c.Append(io.EOF, io.EOF, io.ErrClosedPipe, io.EOF, io.EOF, io.EOF)
+
+	if got := c.Len(); got != 6 {
+		t.Errorf("Len() = %d, want 6", got)
+	}
+	if got := c.Stored(); got != 3 {
+		t.Errorf("Stored() = %d, want 3", got)
+	}
+	want := []string{
+		"EOF (repeated 1 times over 0s)",
+		"io: read/write on closed pipe",
+		"EOF (repeated 2 times over 0s)",
+	}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+}
+
+func TestCollapseConsecutive(t *testing.T) {
+	c := rxmerr.NewCollector(rxmerr.CollapseConsecutive())
+	c.Append(io.EOF, io.EOF, io.EOF, io.ErrClosedPipe, io.EOF)
+
+	want := []string{"EOF (repeated 2 times)", "io: read/write on closed pipe", "EOF"}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	if got := c.Len(); got != 5 {
+		t.Errorf("Len() = %d, want 5", got)
+	}
+}
+
+func TestCollapseConsecutiveEqualMessages(t *testing.T) {
+	first := errors.New("upstream unavailable")
+	second := errors.New("upstream unavailable")
+
+	c := rxmerr.NewCollector(rxmerr.CollapseConsecutive())
+	c.Append(first, second, first)
+
+	want := []string{"upstream unavailable (repeated 2 times)"}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	if got := c.Stored(); got != 1 {
+		t.Errorf("Stored() = %d, want 1", got)
+	}
+	if err := c.Err(); !errors.Is(err, first) {
+		t.Errorf("errors.Is(Err(), first) = false for %v", err)
+	}
+}
+
+func TestCollapseConsecutiveNonConsecutive(t *testing.T) {
+	c := rxmerr.NewCollector(rxmerr.CollapseConsecutive())
+	c.Append(io.EOF, io.ErrClosedPipe, io.EOF, io.ErrClosedPipe)
+
+	want := []string{"EOF", "io: read/write on closed pipe", "EOF", "io: read/write on closed pipe"}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	if got := c.Stored(); got != 4 {
+		t.Errorf("Stored() = %d, want 4", got)
+	}
+}
+
+func TestCollapseConsecutiveReset(t *testing.T) {
+	c := rxmerr.NewCollector(rxmerr.CollapseConsecutive())
+	c.Append(io.EOF, io.EOF)
+	c.Reset()
+	c.Append(io.EOF)
+
+	if got, want := c.Messages(), []string{"EOF"}; !slices.Equal(got, want) {
+		t.Errorf("Messages() after Reset = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// WithCode annotates err with a machine-readable code, such as
+// "DB_UNAVAILABLE" or "invalid_argument".
+//
+// The returned error has the same message as err and unwraps to it, so
+// errors.Is and errors.As keep matching. The code can be read back with
+// CodeOf, also from an aggregate constituent or through further wrapping:
+//
+//	c.Append(rxmerr.WithCode(err, "DB_UNAVAILABLE"))
+//	for _, e := range c.Errors() {
+//	    code, _ := rxmerr.CodeOf(e)
+//	    ...
+//	}
+//
+// If err is nil, WithCode returns nil. An empty code returns err unchanged.
+// Codes MAY be documented with RegisterCode; in strict mode (see
+// SetStrictCodes), annotating with an unregistered code is reported.
+func WithCode(err error, code string) error {
+	coded := withCode(err, code)
+	if coded == err || !strictCodes() {
+		return coded
+	}
+	if invalid := CheckCode(code); invalid != nil {
+		return Combine(coded, invalid)
+	}
+	return coded
+}
+
+// withCode annotates err with code like WithCode, without validating code in
+// strict mode. It is used for codes received from peers, which a local
+// registry does not describe.
+func withCode(err error, code string) error {
+	if err == nil || code == "" {
+		return err
+	}
+	return &codeError{err: err, code: code}
+}
+
+// CodeOf returns the code attached to err with WithCode. If err was annotated
+// several times, the outermost code is returned.
+//
+// CodeOf follows the chain of err with errors.As; for an aggregate it reports
+// the code of the first constituent that carries one. It returns false if no
+// code was found.
+func CodeOf(err error) (string, bool) {
+	var ce *codeError
+	if errors.As(err, &ce) {
+		return ce.code, true
+	}
+	return "", false
+}
+
+// codeError is the error returned by WithCode.
+type codeError struct {
+	err  error
+	code string
+}
+
+func (e *codeError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the annotated error.
+func (e *codeError) Unwrap() error {
+	return e.err
+}
+
+// Format implements fmt.Formatter: %+v formats the annotated error with %+v,
+// %q quotes Error(), and other verbs write Error().
+func (e *codeError) Format(f fmt.State, verb rune) {
+	formatWrapper(f, verb, e, "", e.err)
+}
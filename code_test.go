@@ -0,0 +1,175 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+// The registry is process-wide, so every test registers codes of its own.
+
+// strictCodes enables strict mode for the duration of t.
+func strictCodes(t *testing.T) {
+	rxmerr.SetStrictCodes(true)
+	t.Cleanup(func() { rxmerr.SetStrictCodes(false) })
+}
+
+func TestWithCode(t *testing.T) {
+	if err := rxmerr.WithCode(nil, "TEST_NIL"); err != nil {
+		t.Errorf("WithCode(nil) = %v, want nil", err)
+	}
+	if err := rxmerr.WithCode(io.EOF, ""); err != io.EOF {
+		t.Errorf("WithCode(err, \"\") = %#v, want err unchanged", err)
+	}
+
+	err := fmt.Errorf("load: %w", rxmerr.WithCode(io.EOF, "TEST_EOF"))
+	if got, want := err.Error(), "load: EOF"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Error("errors.Is(err, io.EOF) = false")
+	}
+	if code, ok := rxmerr.CodeOf(err); !ok || code != "TEST_EOF" {
+		t.Errorf("CodeOf() = (%q, %v), want (\"TEST_EOF\", true)", code, ok)
+	}
+	if code, ok := rxmerr.CodeOf(io.EOF); ok {
+		t.Errorf("CodeOf(io.EOF) = (%q, true), want false", code)
+	}
+}
+
+func TestWithCodeStrict(t *testing.T) {
+	rxmerr.RegisterCode("TEST_STRICT_KNOWN", "A registered code.")
+	strictCodes(t)
+
+	err := rxmerr.WithCode(io.EOF, "TEST_STRICT_KNOWN")
+	if got := rxmerr.Errors(err); len(got) != 1 || got[0] != err {
+		t.Errorf("Errors(WithCode()) = %v, want the annotated error alone", got)
+	}
+	if errors.Is(err, rxmerr.ErrUnregisteredCode) {
+		t.Errorf("errors.Is(%v, ErrUnregisteredCode) = true for a registered code", err)
+	}
+
+	err = rxmerr.WithCode(io.EOF, "TEST_STRICT_TYPO")
+	if code, ok := rxmerr.CodeOf(err); !ok || code != "TEST_STRICT_TYPO" {
+		t.Errorf("CodeOf() = (%q, %v), want the code attached in strict mode", code, ok)
+	}
+	if !errors.Is(err, io.EOF) || !errors.Is(err, rxmerr.ErrUnregisteredCode) {
+		t.Errorf("WithCode() = %v, want it to match io.EOF and ErrUnregisteredCode", err)
+	}
+	want := []string{"EOF", `rxmerr: unregistered error code "TEST_STRICT_TYPO"`}
+	if got := rxmerr.Messages(err); !slices.Equal(got, want) {
+		t.Errorf("Messages(WithCode()) = %q, want %q", got, want)
+	}
+}
+
+func TestWithCodeNotStrict(t *testing.T) {
+	err := rxmerr.WithCode(io.EOF, "TEST_LAX_UNKNOWN")
+	if got := rxmerr.Errors(err); len(got) != 1 || got[0] != err {
+		t.Errorf("Errors(WithCode()) = %v, want the annotated error alone", got)
+	}
+}
+
+func TestCheckCode(t *testing.T) {
+	rxmerr.RegisterCode("TEST_CHECK_KNOWN", "A registered code.")
+
+	if err := rxmerr.CheckCode("TEST_CHECK_KNOWN"); err != nil {
+		t.Errorf("CheckCode(registered) = %v, want nil", err)
+	}
+	err := rxmerr.CheckCode("TEST_CHECK_UNKNOWN")
+	if !errors.Is(err, rxmerr.ErrUnregisteredCode) {
+		t.Errorf("CheckCode(unregistered) = %v, want an error wrapping ErrUnregisteredCode", err)
+	}
+}
+
+func TestRegisterCode(t *testing.T) {
+	rxmerr.RegisterCode("TEST_REG_B", "Second.")
+	rxmerr.RegisterCode("TEST_REG_A", "First.")
+	if !rxmerr.RegisterCode("TEST_REG_A", "First.") {
+		t.Error("RegisterCode() with the same description = false, want true")
+	}
+
+	var got []rxmerr.CodeInfo
+	for _, info := range rxmerr.RegisteredCodes() {
+		if info.Code == "TEST_REG_A" || info.Code == "TEST_REG_B" {
+			got = append(got, info)
+		}
+	}
+	want := []rxmerr.CodeInfo{
+		{Code: "TEST_REG_A", Description: "First.", Registered: true},
+		{Code: "TEST_REG_B", Description: "Second.", Registered: true},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("RegisteredCodes() = %v, want %v", got, want)
+	}
+	codes := rxmerr.RegisteredCodes()
+	if !slices.IsSortedFunc(codes, func(a, b rxmerr.CodeInfo) int {
+		return strings.Compare(a.Code, b.Code)
+	}) {
+		t.Errorf("RegisteredCodes() = %v, not sorted by code", codes)
+	}
+}
+
+func TestRegisterCodePanics(t *testing.T) {
+	rxmerr.RegisterCode("TEST_REG_DUP", "Original.")
+
+	tests := []struct {
+		name, code, description string
+	}{
+		{"empty code", "", "Empty."},
+		{"different description", "TEST_REG_DUP", "Changed."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterCode(%q, %q) did not panic", tt.code, tt.description)
+				}
+			}()
+			rxmerr.RegisterCode(tt.code, tt.description)
+		})
+	}
+}
+
+func TestCodesIn(t *testing.T) {
+	rxmerr.RegisterCode("TEST_IN_DB", "The database could not be reached.")
+
+	err := rxmerr.Combine(
+		rxmerr.WithCode(io.EOF, "TEST_IN_DB"),
+		io.ErrClosedPipe,
+		fmt.Errorf("call: %w", rxmerr.WithCode(io.EOF, "TEST_IN_UNKNOWN")),
+		rxmerr.WithCode(io.ErrUnexpectedEOF, "TEST_IN_DB"),
+		rxmerr.WithCode(rxmerr.WithCode(io.EOF, "TEST_IN_INNER"), "TEST_IN_OUTER"),
+	)
+	want := []rxmerr.CodeInfo{
+		{Code: "TEST_IN_DB", Description: "The database could not be reached.", Registered: true},
+		{Code: "TEST_IN_UNKNOWN"},
+		{Code: "TEST_IN_OUTER"},
+	}
+	if got := rxmerr.CodesIn(err); !slices.Equal(got, want) {
+		t.Errorf("CodesIn() = %v, want %v", got, want)
+	}
+	if got := rxmerr.CodesIn(io.EOF); got != nil {
+		t.Errorf("CodesIn(io.EOF) = %v, want nil", got)
+	}
+}
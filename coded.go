@@ -0,0 +1,122 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// CodedError associates an integer code, such as an HTTP status or a gRPC
+// code, with an error. It is created by Collector.AppendCoded.
+//
+// Unlike WithCode, whose string codes leave the message unchanged, CodedError
+// renders its code as part of the message.
+type CodedError struct {
+	Code int
+	Err  error
+}
+
+// Error returns the code in brackets followed by the message, for example
+// "[404] user not found".
+func (e *CodedError) Error() string {
+	return "[" + strconv.Itoa(e.Code) + "] " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *CodedError) Unwrap() error {
+	return e.Err
+}
+
+// Format implements fmt.Formatter: %+v writes the code followed by the %+v
+// output of the underlying error, %q quotes Error(), and other verbs write
+// Error().
+func (e *CodedError) Format(f fmt.State, verb rune) {
+	formatWrapper(f, verb, e, "["+strconv.Itoa(e.Code)+"] ", e.Err)
+}
+
+// AppendCoded appends err wrapped in a *CodedError with the given code:
+//
+//	c.AppendCoded(http.StatusNotFound, err)
+//	c.AppendCoded(http.StatusBadGateway, upstreamErr)
+//	status := slices.Max(c.Codes())
+//
+// A nil err is ignored.
+func (c *Collector) AppendCoded(code int, err error) {
+	if err == nil {
+		return
+	}
+	c.Append(&CodedError{Code: code, Err: err})
+}
+
+// ErrorsByCode returns the collected errors whose code is code, in the order
+// returned by Errors, or nil if there are none. The code of an error is that
+// of the first *CodedError in its chain, as found by errors.As.
+func (c *Collector) ErrorsByCode(code int) []error {
+	var out []error
+	for _, err := range c.Errors() {
+		if got, ok := codedOf(err); ok && got == code {
+			out = append(out, err)
+		}
+	}
+	return out
+}
+
+// Codes returns the distinct codes of the collected errors (see
+// ErrorsByCode), in order of first occurrence, or nil if no collected error
+// has a code.
+func (c *Collector) Codes() []int {
+	var codes []int
+	for _, err := range c.Errors() {
+		if code, ok := codedOf(err); ok && !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+// IsCode reports whether err, or any error in its tree, is a *CodedError with
+// the given code. Nested aggregates and wrapped errors are searched.
+func IsCode(err error, code int) bool {
+	switch e := err.(type) {
+	case nil:
+		return false
+	case *CodedError:
+		if e.Code == code {
+			return true
+		}
+		return IsCode(e.Err, code)
+	case interface{ Unwrap() []error }:
+		return slices.ContainsFunc(e.Unwrap(), func(inner error) bool {
+			return IsCode(inner, code)
+		})
+	case interface{ Unwrap() error }:
+		return IsCode(e.Unwrap(), code)
+	}
+	return false
+}
+
+// codedOf returns the code of the first *CodedError in the chain of err.
+func codedOf(err error) (int, bool) {
+	var ce *CodedError
+	if errors.As(err, &ce) {
+		return ce.Code, true
+	}
+	return 0, false
+}
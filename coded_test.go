@@ -0,0 +1,105 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestAppendCoded(t *testing.T) {
+	errUser := errors.New("user not found")
+	c := rxmerr.NewCollector()
+	c.AppendCoded(http.StatusNotFound, errUser)
+	c.AppendCoded(http.StatusBadGateway, io.ErrUnexpectedEOF)
+	c.AppendCoded(http.StatusNotFound, io.EOF)
+	c.AppendCoded(http.StatusTeapot, nil)
+	c.Append(io.ErrClosedPipe)
+
+	want := []string{"[404] user not found", "[502] unexpected EOF", "[404] EOF", "io: read/write on closed pipe"}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	if got, want := c.Codes(), []int{404, 502}; !slices.Equal(got, want) {
+		t.Errorf("Codes() = %v, want %v", got, want)
+	}
+
+	byCode := c.ErrorsByCode(http.StatusNotFound)
+	if len(byCode) != 2 || !errors.Is(byCode[0], errUser) || !errors.Is(byCode[1], io.EOF) {
+		t.Errorf("ErrorsByCode(404) = %v, want the two 404 errors", byCode)
+	}
+	if got := c.ErrorsByCode(http.StatusTeapot); got != nil {
+		t.Errorf("ErrorsByCode(418) = %v, want nil", got)
+	}
+}
+
+func TestCollectorCodesEmpty(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(io.EOF)
+	if got := c.Codes(); got != nil {
+		t.Errorf("Codes() without coded errors = %v, want nil", got)
+	}
+}
+
+func TestIsCode(t *testing.T) {
+	notFound := &rxmerr.CodedError{Code: 404, Err: io.EOF}
+	nested := &rxmerr.CodedError{Code: 500, Err: &rxmerr.CodedError{Code: 503, Err: io.EOF}}
+
+	tests := []struct {
+		name string
+		err  error
+		code int
+		want bool
+	}{
+		{"nil", nil, 404, false},
+		{"uncoded", io.EOF, 404, false},
+		{"direct", notFound, 404, true},
+		{"other code", notFound, 500, false},
+		{"wrapped", fmt.Errorf("get user: %w", notFound), 404, true},
+		{"in aggregate", rxmerr.Combine(io.EOF, notFound), 404, true},
+		{"in nested aggregate", rxmerr.Combine(io.EOF, errors.Join(io.EOF, notFound)), 404, true},
+		{"outer code", nested, 500, true},
+		{"inner code", nested, 503, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rxmerr.IsCode(tt.err, tt.code); got != tt.want {
+				t.Errorf("IsCode(%v, %d) = %v, want %v", tt.err, tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodedErrorFormat(t *testing.T) {
+	err := &rxmerr.CodedError{Code: 500, Err: &stackErr{msg: "boom"}}
+	for _, tt := range []struct{ format, want string }{
+		{"%v", "[500] boom"},
+		{"%s", "[500] boom"},
+		{"%q", `"[500] boom"`},
+		{"%+v", "[500] boom\nmain.run\n\tmain.go:12"},
+	} {
+		if got := fmt.Sprintf(tt.format, err); got != tt.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
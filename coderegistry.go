@@ -0,0 +1,198 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrUnregisteredCode is returned by CheckCode, and reported by WithCode in
+// strict mode, for a code that was not registered with RegisterCode. See
+// SetStrictCodes.
+var ErrUnregisteredCode = errors.New("rxmerr: unregistered error code")
+
+// CodeInfo describes an error code used with WithCode.
+type CodeInfo struct {
+	// Code is the machine-readable code.
+	Code string
+
+	// Description is the description given to RegisterCode, or empty if the
+	// code is not registered.
+	Description string
+
+	// Registered reports whether the code was registered with RegisterCode.
+	Registered bool
+}
+
+// codeRegistry holds the codes registered with RegisterCode.
+var codeRegistry struct {
+	mu     sync.RWMutex
+	codes  map[string]string // code to description
+	strict bool              // set by SetStrictCodes
+}
+
+// RegisterCode registers an error code and its human-readable description,
+// making the set of codes an application can emit discoverable, for example
+// by an API documentation generator via RegisteredCodes.
+//
+// Codes are typically registered from package-level variable declarations or
+// init functions, next to the code that emits them:
+//
+//	var _ = rxmerr.RegisterCode("DB_UNAVAILABLE", "The database could not be reached.")
+//
+// Registering the same code twice with the same description is a no-op.
+// RegisterCode panics if code is empty or was already registered with a
+// different description, as both indicate a programming error. It always
+// returns true, so that it can be used in a variable declaration.
+//
+// RegisterCode is safe for concurrent use.
+func RegisterCode(code, description string) bool {
+	if code == "" {
+		panic("rxmerr: RegisterCode with empty code")
+	}
+
+	codeRegistry.mu.Lock()
+	defer codeRegistry.mu.Unlock()
+
+	if prev, ok := codeRegistry.codes[code]; ok {
+		if prev != description {
+			panic(fmt.Sprintf("rxmerr: error code %q registered twice with different descriptions", code))
+		}
+		return true
+	}
+	if codeRegistry.codes == nil {
+		codeRegistry.codes = make(map[string]string)
+	}
+	codeRegistry.codes[code] = description
+	return true
+}
+
+// RegisteredCodes returns all codes registered with RegisterCode, sorted by
+// code. It returns nil if no code is registered.
+func RegisteredCodes() []CodeInfo {
+	codeRegistry.mu.RLock()
+	defer codeRegistry.mu.RUnlock()
+
+	if len(codeRegistry.codes) == 0 {
+		return nil
+	}
+	out := make([]CodeInfo, 0, len(codeRegistry.codes))
+	for code, description := range codeRegistry.codes {
+		out = append(out, CodeInfo{Code: code, Description: description, Registered: true})
+	}
+	slices.SortFunc(out, func(a, b CodeInfo) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+	return out
+}
+
+// SetStrictCodes enables or disables strict mode for WithCode.
+//
+// In strict mode, WithCode validates codes against the registry. Annotating
+// an error with an unregistered code still attaches the code, but the
+// returned error is combined with the error returned by CheckCode, so that
+// the mistake surfaces wherever the error is logged or tested:
+//
+//	rxmerr.SetStrictCodes(true)
+//	err := rxmerr.WithCode(errA, "TYPO")
+//	errors.Is(err, rxmerr.ErrUnregisteredCode) // true
+//
+// Codes decoded from peers, such as by DecodeHeader, are not validated.
+// Strict mode is a debugging aid meant for tests and development builds; it
+// is off by default. Use CheckCode to validate codes regardless of strict
+// mode. SetStrictCodes is safe for concurrent use.
+func SetStrictCodes(strict bool) {
+	codeRegistry.mu.Lock()
+	codeRegistry.strict = strict
+	codeRegistry.mu.Unlock()
+}
+
+// strictCodes reports whether strict mode is enabled.
+func strictCodes() bool {
+	codeRegistry.mu.RLock()
+	defer codeRegistry.mu.RUnlock()
+	return codeRegistry.strict
+}
+
+// CheckCode returns an error wrapping ErrUnregisteredCode that names code if
+// code was not registered with RegisterCode, or nil otherwise. Unlike strict
+// mode, CheckCode does not depend on SetStrictCodes:
+//
+//	for _, code := range handlerCodes {
+//	    if err := rxmerr.CheckCode(code); err != nil {
+//	        t.Error(err)
+//	    }
+//	}
+//
+// CheckCode is safe for concurrent use.
+func CheckCode(code string) error {
+	codeRegistry.mu.RLock()
+	defer codeRegistry.mu.RUnlock()
+
+	if _, ok := codeRegistry.codes[code]; ok {
+		return nil
+	}
+	return fmt.Errorf("%w %q", ErrUnregisteredCode, code)
+}
+
+// CodesIn returns the distinct codes attached with WithCode anywhere in err,
+// in order of first occurrence, resolved to their registered descriptions.
+// Codes that are not registered are returned with Registered set to false.
+//
+// Like CodeOf, CodesIn reports the outermost code of each branch: a code
+// attached to an error that was later annotated again is not reported. It
+// returns nil if err carries no code.
+func CodesIn(err error) []CodeInfo {
+	var codes []string
+	walkCodes(err, func(code string) {
+		if !slices.Contains(codes, code) {
+			codes = append(codes, code)
+		}
+	})
+	if len(codes) == 0 {
+		return nil
+	}
+
+	codeRegistry.mu.RLock()
+	defer codeRegistry.mu.RUnlock()
+
+	out := make([]CodeInfo, len(codes))
+	for i, code := range codes {
+		description, ok := codeRegistry.codes[code]
+		out[i] = CodeInfo{Code: code, Description: description, Registered: ok}
+	}
+	return out
+}
+
+// walkCodes calls fn with the outermost code of every branch of err.
+func walkCodes(err error, fn func(code string)) {
+	switch e := err.(type) {
+	case nil:
+	case *codeError:
+		fn(e.code)
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			walkCodes(inner, fn)
+		}
+	case interface{ Unwrap() error }:
+		walkCodes(e.Unwrap(), fn)
+	}
+}
@@ -16,13 +16,21 @@
 
 package rxmerr
 
-import "go.uber.org/multierr"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/multierr"
+)
 
 // Collector incrementally accumulates non-nil errors and exposes them as a
 // single aggregated error.
 //
 // This type is intended for simple, sequential use within a single goroutine.
-// It wraps multierr.Append/Errors to provide a more ergonomic, stateful API:
+// It wraps multierr.Combine/Errors to provide a more ergonomic, stateful API:
 //
 //	c := rxmerr.NewCollector()
 //	c.Append(op1())
@@ -38,33 +46,187 @@ import "go.uber.org/multierr"
 // using a mutex) or use a separate Collector per goroutine and merge their
 // final errors with multierr.Append at the end.
 type Collector struct {
-	err   error // aggregated error built via multierr.Append
-	count int   // number of non-nil errors that were appended
+	errs  []error // stored errors, in collection order
+	err   error   // aggregate of errs built by aggregate; nil when stale
+	reps  []int   // interned repeats per entry of errs; nil if none, see Counted
+	count int     // number of non-nil errors that were appended
+
+	ignore     []func(error) bool  // append-time filters set by WithIgnore
+	transforms []func(error) error // append-time transformations set by options
+	context    string              // message applied to the aggregate by Err, if set
+	prefix     string              // message applied to appended errors, see Prefix
+
+	ctx        context.Context // source of logged values set by WithContext
+	ctxKeys    []any           // keys of logged context values, see WithContextKeys
+	ctxAborted bool            // set by AppendContext once its context is done
+
+	stamp func(context.Context) string // annotation used by AppendCtx, see WithContextStamp
+
+	joinBackend bool // build the aggregate with JoinCombine, see WithJoinBackend
+
+	clock    func() time.Time // time source set by WithClock; nil for time.Now
+	coalesce *coalesceConfig  // set by WithCoalesce
+	run      coalesceRun      // current run of repeated errors, for coalescing
+
+	maxRate   *maxRateConfig   // set by MaxRate
+	rateLimit *rateLimitConfig // set by WithRateLimit
+
+	maxBytes     int // bound on stored message bytes set by WithMaxBytes
+	storedBytes  int // message bytes of stored errors, tracked with maxBytes
+	droppedBytes int // message bytes of errors dropped because of maxBytes
+
+	weight       int // total weight of appended errors, see AppendWeighted
+	weightBudget int // bound on weight set by WithWeightBudget
+
+	sealed     bool       // set by Seal
+	sealPolicy SealPolicy // set by WithSealPolicy
+
+	success bool // set by SuccessMode; appends are no-ops while set
+
+	autoExpiry *autoExpiry // set by StartAutoExpire
+
+	pipes []pipe // collectors that also receive appended errors, see Pipe and Wrap
 }
 
-// NewCollector creates a new, empty Collector.
+// NewCollector creates a new, empty Collector configured with opts.
 //
 // The returned instance contains no errors (Err() returns nil, Len() returns 0)
 // and is ready for use. A single Collector MAY be reused across multiple
 // logical operations by calling Reset between uses.
-func NewCollector() *Collector {
-	return &Collector{}
+//
+// Options are applied in the order they are given; see Option for how the
+// stages they install interact. A zero-value Collector is equivalent to
+// NewCollector() without options.
+func NewCollector(opts ...Option) *Collector {
+	c := &Collector{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Append adds the provided errors to the collector, in order.
+//
+// Nil errors are skipped; if all errs are nil, Append is a no-op and does not
+// change the internal state. Each non-nil error is passed through the
+// pipeline configured by options (if any, see Option), stored for
+// aggregation, and the internal count of non-nil errors is incremented.
+// Errors discarded by options are not counted. Several results can be
+// appended in a single call:
+//
+//	c.Append(op1(), op2(), op3())
+//
+// Apart from configured options, Collector does not interpret, wrap, or filter
+// errors by itself; all behavior related to aggregation (ordering, flattening,
+// etc.) is delegated to go.uber.org/multierr.
+func (c *Collector) Append(errs ...error) {
+	for _, err := range errs {
+		if err != nil {
+			callDebugHook(err)
+			c.add(err, 1)
+		}
+	}
 }
 
-// Append adds the provided error to the collector.
+// AppendBatch adds all non-nil errors from errs to the collector.
 //
-// If err is nil, Append is a no-op and does not change the internal state.
-// If err is non-nil, it is added to the aggregated error using multierr.Append
-// and the internal count of non-nil errors is incremented.
+// The result is equivalent to calling Append for each element of errs in
+// order, but storage is grown at most once for the whole batch instead of
+// once per error. In hot loops this reduces the number of allocations.
 //
-// Collector does not interpret, wrap, or filter errors by itself; all behavior
-// related to aggregation (ordering, flattening, etc.) is delegated to
-// go.uber.org/multierr.
-func (c *Collector) Append(err error) {
-	if err != nil {
-		c.err = multierr.Append(c.err, err)
-		c.count++
+// Nil elements are skipped. If errs contains no non-nil errors, AppendBatch is
+// a no-op. The errs slice is not retained or modified.
+func (c *Collector) AppendBatch(errs []error) {
+	c.errs = slices.Grow(c.errs, len(errs))
+	for _, err := range errs {
+		if err != nil {
+			callDebugHook(err)
+			c.add(err, 1)
+		}
+	}
+}
+
+// add prepares, stores, and counts a non-nil error with the given weight,
+// then forwards the prepared error to the collectors it is piped to, if any.
+func (c *Collector) add(err error, weight int) {
+	c.expireIfDue()
+	if c.success {
+		return
+	}
+	if c.sealed {
+		c.rejectSealed(err)
+		return
+	}
+	if err = c.prepare(err); err == nil {
+		return
+	}
+	if !c.allowRateLimit() {
+		return
+	}
+	c.store(err)
+	c.count++
+	c.weight += weight
+	c.forward(err, weight)
+}
+
+// prepare runs the ignore and transform stages of the option pipeline and
+// applies the prefix set with Prefix. It returns nil if err was discarded.
+func (c *Collector) prepare(err error) error {
+	for _, ignore := range c.ignore {
+		if ignore(err) {
+			return nil
+		}
+	}
+	for _, transform := range c.transforms {
+		if err = transform(err); err == nil {
+			return nil
+		}
+	}
+	if c.prefix != "" {
+		err = fmt.Errorf("%s: %w", c.prefix, err)
 	}
+	return err
+}
+
+// store runs the merge and storage stages of the option pipeline for a
+// prepared, non-nil error, and invalidates the cached aggregate if the error
+// was stored.
+func (c *Collector) store(err error) {
+	if c.coalesce != nil && c.coalesceInto(err) {
+		return
+	}
+	if !c.fitsMaxRate() || !c.fitsMaxBytes(err) {
+		c.run = coalesceRun{}
+		return
+	}
+	if c.internInto(err) {
+		return
+	}
+	c.errs = append(c.errs, err)
+	if c.reps != nil {
+		c.reps = append(c.reps, 0)
+	}
+	c.err = nil
+}
+
+// aggregate returns the aggregate of the stored errors, building it with
+// multierr.Combine (or JoinCombine, see WithJoinBackend) if the cached value
+// is stale. Interned repeats are materialized, and the MaxRate note is added.
+// It returns nil if no errors are stored or dropped.
+func (c *Collector) aggregate() error {
+	c.expireIfDue()
+	if c.err == nil && (len(c.errs) > 0 || c.RateDropped() > 0) {
+		errs := c.expanded()
+		if note := c.rateNote(); note != nil {
+			errs = append(errs[:len(errs):len(errs)], note)
+		}
+		if c.joinBackend {
+			c.err = JoinCombine(errs...)
+		} else {
+			c.err = multierr.Combine(errs...)
+		}
+	}
+	return c.err
 }
 
 // AppendFunc calls fn and appends its returned error to the collector.
@@ -89,13 +251,83 @@ func (c *Collector) AppendFunc(fn func() error) {
 //
 // If no non-nil errors were appended, Err returns nil. If one or more
 // non-nil errors were appended, Err returns an error value constructed via
-// multierr.Append. Callers MAY use multierr.Errors(err) on the returned error
+// multierr.Combine, or via JoinCombine if the collector was created with
+// WithJoinBackend. Callers MAY use multierr.Errors(err) on the returned error
 // to inspect all underlying errors if needed.
 //
-// Err does not reset the collector state; multiple calls return the same
-// aggregated error until new errors are appended or Reset is called.
+// If a context message was set with SetContext, the aggregate is wrapped like
+// fmt.Errorf("%s: %w", msg, aggregate); the wrapped errors remain reachable via
+// errors.Is and errors.As. Unlike a plain fmt.Errorf wrapper, the result
+// keeps the %+v output of the aggregate, which lists every constituent with
+// its own %+v output, so details such as stack traces are not lost.
+//
+// Err does not reset the collector state; multiple calls return equivalent
+// aggregated errors until new errors are appended or Reset is called.
 func (c *Collector) Err() error {
-	return c.err
+	err := c.aggregate()
+	if err != nil && c.context != "" {
+		return &annotatedError{msg: c.context, err: err}
+	}
+	return err
+}
+
+// ErrIfAtLeast returns Err if at least n errors were collected (see Len), and
+// nil otherwise. This expresses "tolerate up to n-1 failures" for best-effort
+// work:
+//
+//	for _, peer := range peers {
+//	    c.Append(peer.Notify(ev))
+//	}
+//	return c.ErrIfAtLeast(3) // a couple of unreachable peers are fine
+//
+// A non-positive n behaves like Err.
+func (c *Collector) ErrIfAtLeast(n int) error {
+	if c.Len() < n {
+		return nil
+	}
+	return c.Err()
+}
+
+// SetContext sets a message that Err applies to the aggregate when it is read.
+//
+// The context is applied lazily: it does not affect errors as they are
+// appended, nor the constituents returned by Errors, and it is only used when
+// at least one error was collected. Setting an empty msg removes the context:
+//
+//	c := rxmerr.NewCollector()
+//	c.SetContext("sync routes")
+//	c.Append(errA)
+//	c.Err()    // "sync routes: <errA>"
+//	c.Errors() // []error{errA}
+func (c *Collector) SetContext(msg string) {
+	c.context = msg
+}
+
+// Prefix sets a prefix that is applied to every error appended from now on,
+// as fmt.Errorf("%s: %w", prefix, err).
+//
+// Unlike SetContext, which annotates the aggregate when it is read, Prefix
+// annotates individual errors as they are appended, and errors that were
+// already collected keep their messages. Calling Prefix again changes the
+// prefix for subsequent appends:
+//
+//	c.Prefix("load")
+//	c.Append(errA) // "load: <errA>"
+//	c.Prefix("save")
+//	c.Append(errB) // "save: <errB>"
+//
+// The prefix is applied after the transform stages of the option pipeline,
+// so it is the outermost annotation. Prefix mutates the collector, which
+// suits a single-use collector in a clear sequential scope; use Wrap to hand
+// a separately annotated collector to a sub-system. An empty prefix is
+// equivalent to ClearPrefix.
+func (c *Collector) Prefix(prefix string) {
+	c.prefix = prefix
+}
+
+// ClearPrefix removes the prefix set with Prefix from subsequent appends.
+func (c *Collector) ClearPrefix() {
+	c.prefix = ""
 }
 
 // Len returns the number of non-nil errors that have been collected so far.
@@ -103,10 +335,27 @@ func (c *Collector) Err() error {
 // This is a simple counter that increments each time Append is called with a
 // non-nil error (or AppendFunc returns a non-nil error). After Reset, Len
 // returns 0 until new errors are appended.
+//
+// Len counts logical occurrences: an error that was coalesced into a previous
+// entry (see WithCoalesce) still counts. Use Stored to get the number of
+// physically stored entries.
 func (c *Collector) Len() int {
+	c.expireIfDue()
 	return c.count
 }
 
+// Stored returns the number of entries physically stored by the collector.
+//
+// Without options that merge or drop errors, Stored equals Len unless the same
+// error value was appended several times in a row: such runs are interned into
+// a single entry, see Counted. With WithCoalesce, a run of repeated errors
+// occupies a single entry, and with WithMaxBytes, errors that do not fit are
+// not stored at all; in all these cases Stored MAY be smaller than Len.
+func (c *Collector) Stored() int {
+	c.expireIfDue()
+	return len(c.errs)
+}
+
 // HasError reports whether at least one non-nil error has been collected.
 //
 // This is a convenience predicate equivalent to:
@@ -116,6 +365,7 @@ func (c *Collector) Len() int {
 // It is often useful for quick checks when the aggregated error value itself
 // is not needed.
 func (c *Collector) HasError() bool {
+	c.expireIfDue()
 	return c.count > 0
 }
 
@@ -124,28 +374,228 @@ func (c *Collector) HasError() bool {
 // After Reset, the collector behaves as if it was newly created:
 //   - Err() returns nil;
 //   - Len() returns 0;
-//   - HasError() returns false.
+//   - HasError() returns false;
+//   - no context message (see SetContext) or prefix (see Prefix) is set;
+//   - the collector is not in success mode (see SuccessMode).
 //
 // Any error value previously returned by Err remains valid and independent;
-// calling Reset does NOT mutate already returned error instances.
+// calling Reset does NOT mutate already returned error instances. Options the
+// collector was created with remain in effect, and a sealed collector (see
+// Seal) stays sealed.
 func (c *Collector) Reset() {
+	c.errs = nil
+	c.reps = nil
 	c.err = nil
 	c.count = 0
+	c.weight = 0
+	c.ctxAborted = false
+	c.context = ""
+	c.prefix = ""
+	c.success = false
+	c.run = coalesceRun{}
+	c.storedBytes = 0
+	c.droppedBytes = 0
+	c.resetMaxRate()
+	c.resetRateLimit()
 }
 
 // Errors returns all collected non-nil errors as a slice.
 //
-// If no errors were collected, Errors returns nil. Otherwise it delegates to
-// multierr.Errors to extract the underlying error slice from the aggregated
-// error stored in the collector.
+// If no errors were collected, Errors returns nil. Otherwise the result holds
+// one element per stored error, in the order they were appended, followed by
+// the note added while MaxRate drops errors, if any. An appended error that is
+// itself an aggregate (for example the result of Combine or errors.Join) is
+// kept as a single element, so that len(c.Errors()) agrees with Len unless
+// errors were coalesced or dropped, and Combine(c.Errors()...) has the same
+// message as Err. Use the package-level Errors function on Err to get the
+// fully flattened list.
 //
-// The returned slice SHOULD be treated as read-only by callers. Its ordering
-// and exact behavior are defined by go.uber.org/multierr; callers SHOULD NOT
-// rely on a particular concrete implementation beyond what multierr
-// documents.
+// The returned slice is a fresh copy: modifying it does not affect the
+// collector or aggregates previously returned by Err.
 func (c *Collector) Errors() []error {
-	if c.err == nil {
+	c.expireIfDue()
+	if len(c.errs) == 0 {
+		return nil
+	}
+	errs := c.storedErrors()
+	if note := c.rateNote(); note != nil {
+		errs = append(errs, note)
+	}
+	return errs
+}
+
+// Since returns the errors appended after marker, a value previously returned
+// by Len, in the order returned by Errors:
+//
+//	mark := c.Len()
+//	runStep(c)
+//	for _, err := range c.Since(mark) {
+//	    report(err)
+//	}
+//
+// A marker of 0 or less returns all errors; a marker at or beyond the current
+// number of errors returns nil. The returned slice is a fresh copy.
+//
+// Markers assume that every append is stored as one entry. Options that merge
+// or drop errors (see Stored) and destructive edits such as TruncateAt break
+// that assumption, and Since then returns the stored entries after position
+// marker.
+func (c *Collector) Since(marker int) []error {
+	errs := c.storedErrors()
+	if marker >= len(errs) {
+		return nil
+	}
+	return errs[max(marker, 0):]
+}
+
+// Messages returns the Error() strings of the collected errors, in the order
+// returned by Errors, or nil if no errors were collected.
+func (c *Collector) Messages() []string {
+	errs := c.Errors()
+	if errs == nil {
 		return nil
 	}
-	return multierr.Errors(c.err)
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return msgs
+}
+
+// Each calls fn for every collected error in the order returned by Errors.
+//
+// Iteration stops early if fn returns false. Each does nothing if no errors
+// were collected.
+func (c *Collector) Each(fn func(err error) bool) {
+	for _, err := range c.Errors() {
+		if !fn(err) {
+			return
+		}
+	}
+}
+
+// Contains reports whether any collected error matches target according to
+// errors.Is.
+func (c *Collector) Contains(target error) bool {
+	err := c.aggregate()
+	return err != nil && errors.Is(err, target)
+}
+
+// Report returns a human-readable, multi-line description of all collected
+// errors, suitable for logs and test failure messages.
+//
+// The report is the Format rendering of the collected errors; it lists one
+// numbered error per line and uses the same layout as the %+v output of
+// CombineVerbose:
+//
+//	2 errors occurred:
+//	  [0] open config.yaml: permission denied
+//	  [1] dial tcp 10.0.0.7:443: i/o timeout
+//
+// If no errors were collected, Report returns an empty string.
+func (c *Collector) Report() string {
+	return Format(c.aggregate())
+}
+
+// Wrap returns a child collector that annotates errors with context.
+//
+// Every non-nil error appended to the child is wrapped as
+// fmt.Errorf("%s: %w", context, err) and then stored both in the child and in
+// c, so a sub-system can be handed its own collector while the parent keeps
+// accumulating everything:
+//
+//	db := c.Wrap("db")
+//	db.Append(err)       // c.Errors() contains "db: <err>"
+//	q := db.Wrap("query")
+//	q.Append(err)        // c.Errors() contains "db: query: <err>"
+//
+// Contexts of nested children compound from the outermost to the innermost.
+// The child does not inherit the options of c, but errors forwarded to c go
+// through c's own options. Resetting the child does not affect c, and vice
+// versa. The child is piped to c as if by Pipe, so it MAY be piped to further
+// collectors.
+func (c *Collector) Wrap(context string) *Collector {
+	return &Collector{
+		transforms: []func(error) error{func(err error) error {
+			return fmt.Errorf("%s: %w", context, err)
+		}},
+		pipes: []pipe{{dst: c}},
+	}
+}
+
+// IfErr calls fn with the aggregated error if at least one error has been
+// collected, and returns c to allow chaining:
+//
+//	c.IfErr(func(err error) { log.Print(err) }).
+//	    IfNoErr(metrics.RecordSuccess)
+//
+// The argument passed to fn is the value returned by Err.
+func (c *Collector) IfErr(fn func(err error)) *Collector {
+	if c.HasError() {
+		fn(c.Err())
+	}
+	return c
+}
+
+// IfNoErr calls fn if no errors have been collected, and returns c to allow
+// chaining. See IfErr.
+func (c *Collector) IfNoErr(fn func()) *Collector {
+	if !c.HasError() {
+		fn()
+	}
+	return c
+}
+
+// MustBeEmpty panics if any errors have been collected, and returns normally
+// otherwise:
+//
+//	for _, f := range fixtures {
+//	    c.Append(load(f))
+//	}
+//	c.MustBeEmpty()
+//
+// The panic value is a string containing the Report output, so every
+// collected error is visible in the crash message. MustBeEmpty is a terse
+// guard for tests and scripts that expect clean aggregation; production code
+// SHOULD return Err instead.
+func (c *Collector) MustBeEmpty() {
+	if c.HasError() {
+		panic("rxmerr: unexpected errors collected:\n" + c.Report())
+	}
+}
+
+// Log calls logFn once per collected error, in the order returned by Errors.
+//
+// Each call receives the error message and structured key-value arguments
+// describing the error's position in the collection:
+//
+//	logFn(err.Error(), "index", i, "total", n)
+//
+// This matches the calling convention of log/slog and of most structured
+// loggers, for example:
+//
+//	c.Log(logger.Error)
+//
+// Context values selected with WithContext and WithContextKeys are appended
+// to the arguments. If no errors were collected, logFn is not called.
+func (c *Collector) Log(logFn func(msg string, args ...any)) {
+	errs := c.Errors()
+	ctxArgs := c.contextArgs()
+	for i, err := range errs {
+		logFn(err.Error(), append([]any{"index", i, "total", len(errs)}, ctxArgs...)...)
+	}
+}
+
+// LogAll calls logFn once with a multi-line summary of all collected errors,
+// as rendered by Report, and the number of collected errors as a structured
+// argument:
+//
+//	logFn(c.Report(), "count", c.Len())
+//
+// As with Log, context values selected with WithContextKeys are appended.
+// If no errors were collected, logFn is not called.
+func (c *Collector) LogAll(logFn func(msg string, args ...any)) {
+	if report := c.Report(); report != "" {
+		logFn(report, append([]any{"count", c.Len()}, c.contextArgs()...)...)
+	}
 }
@@ -0,0 +1,225 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestSetContext(t *testing.T) {
+	errA := errors.New("a")
+	c := rxmerr.NewCollector()
+	c.SetContext("sync routes")
+
+	if err := c.Err(); err != nil {
+		t.Fatalf("Err() on empty collector = %v, want nil", err)
+	}
+
+	c.Append(errA, io.EOF)
+	err := c.Err()
+	if got, want := err.Error(), "sync routes: a; EOF"; got != want {
+		t.Errorf("Err() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, errA) || !errors.Is(err, io.EOF) {
+		t.Errorf("Err() = %v, constituents not reachable via errors.Is", err)
+	}
+	if got := c.Errors(); !slices.Equal(got, []error{errA, io.EOF}) {
+		t.Errorf("Errors() = %v, want the unannotated constituents", got)
+	}
+}
+
+func TestSetContextLazy(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(io.EOF)
+	c.SetContext("read config")
+
+	if got, want := c.Err().Error(), "read config: EOF"; got != want {
+		t.Errorf("Err() = %q, want %q", got, want)
+	}
+
+	c.SetContext("")
+	if got := c.Err(); got != io.EOF {
+		t.Errorf("Err() after clearing the context = %v, want io.EOF", got)
+	}
+}
+
+func TestMustBeEmpty(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(nil)
+	c.MustBeEmpty()
+
+	c.Append(errors.New("a"), io.EOF)
+	defer func() {
+		r := recover()
+		msg, ok := r.(string)
+		if !ok {
+			t.Fatalf("MustBeEmpty() panicked with %#v, want a string", r)
+		}
+		want := "rxmerr: unexpected errors collected:\n" + c.Report()
+		if msg != want {
+			t.Errorf("panic message = %q, want %q", msg, want)
+		}
+		for _, s := range []string{"a", "EOF"} {
+			if !strings.Contains(msg, s) {
+				t.Errorf("panic message = %q, want it to contain %q", msg, s)
+			}
+		}
+	}()
+	c.MustBeEmpty()
+	t.Error("MustBeEmpty() did not panic with errors collected")
+}
+
+func TestMustBeEmptyAfterReset(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(io.EOF)
+	c.Reset()
+	c.MustBeEmpty()
+}
+
+func TestSince(t *testing.T) {
+	errA, errB, errC := errors.New("a"), errors.New("b"), errors.New("c")
+	c := rxmerr.NewCollector()
+	if got := c.Since(0); got != nil {
+		t.Errorf("Since(0) on empty collector = %v, want nil", got)
+	}
+
+	c.Append(errA)
+	mark := c.Len()
+	c.Append(nil, errB, errC)
+
+	tests := []struct {
+		marker int
+		want   []error
+	}{
+		{-1, []error{errA, errB, errC}},
+		{0, []error{errA, errB, errC}},
+		{mark, []error{errB, errC}},
+		{2, []error{errC}},
+		{3, nil},
+		{10, nil},
+	}
+	for _, tt := range tests {
+		if got := c.Since(tt.marker); !slices.Equal(got, tt.want) {
+			t.Errorf("Since(%d) = %v, want %v", tt.marker, got, tt.want)
+		}
+	}
+
+	got := c.Since(mark)
+	got[0] = nil
+	if got := c.Since(mark); !slices.Equal(got, []error{errB, errC}) {
+		t.Errorf("Since(%d) after modifying a previous result = %v", mark, got)
+	}
+}
+
+func TestSinceIncremental(t *testing.T) {
+	c := rxmerr.NewCollector()
+	var reported []string
+	for step := range 3 {
+		mark := c.Len()
+		for i := range step + 1 {
+			c.Append(fmt.Errorf("step %d: error %d", step, i))
+		}
+		for _, err := range c.Since(mark) {
+			reported = append(reported, err.Error())
+		}
+	}
+
+	if got, want := reported, c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("reported %q, want every error exactly once: %q", got, want)
+	}
+}
+
+func TestErrIfAtLeast(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(errors.New("a"), errors.New("b"))
+
+	tests := []struct {
+		n       int
+		wantErr bool
+	}{
+		{n: -1, wantErr: true},
+		{n: 0, wantErr: true},
+		{n: 1, wantErr: true},
+		{n: 2, wantErr: true},
+		{n: 3, wantErr: false},
+	}
+	for _, tt := range tests {
+		err := c.ErrIfAtLeast(tt.n)
+		if !tt.wantErr {
+			if err != nil {
+				t.Errorf("ErrIfAtLeast(%d) with %d errors = %v, want nil", tt.n, c.Len(), err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != c.Err().Error() {
+			t.Errorf("ErrIfAtLeast(%d) with %d errors = %v, want %v", tt.n, c.Len(), err, c.Err())
+		}
+	}
+}
+
+func TestErrIfAtLeastEmpty(t *testing.T) {
+	c := rxmerr.NewCollector()
+	for _, n := range []int{-1, 0, 1} {
+		if err := c.ErrIfAtLeast(n); err != nil {
+			t.Errorf("ErrIfAtLeast(%d) on empty collector = %v, want nil", n, err)
+		}
+	}
+}
+
+func TestIfErr(t *testing.T) {
+	tests := []struct {
+		name      string
+		errs      []error
+		wantErr   bool
+		wantNoErr bool
+	}{
+		{name: "empty", wantNoErr: true},
+		{name: "nil only", errs: []error{nil}, wantNoErr: true},
+		{name: "non-empty", errs: []error{errors.New("a"), io.EOF}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := rxmerr.NewCollector()
+			c.Append(tt.errs...)
+
+			var gotErr error
+			calledErr, calledNoErr := false, false
+			got := c.IfErr(func(err error) {
+				calledErr = true
+				gotErr = err
+			}).IfNoErr(func() { calledNoErr = true })
+
+			if got != c {
+				t.Errorf("IfErr().IfNoErr() = %p, want the collector %p", got, c)
+			}
+			if calledErr != tt.wantErr || calledNoErr != tt.wantNoErr {
+				t.Errorf("IfErr called = %v, IfNoErr called = %v, want %v and %v", calledErr, calledNoErr, tt.wantErr, tt.wantNoErr)
+			}
+			if tt.wantErr && (gotErr == nil || gotErr.Error() != c.Err().Error()) {
+				t.Errorf("IfErr passed %v, want %v", gotErr, c.Err())
+			}
+		})
+	}
+}
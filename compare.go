@@ -0,0 +1,285 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// CompareWith compares the collected errors against expected and returns the
+// symmetric difference between the two sets.
+//
+// missing contains the elements of expected that have no counterpart among
+// the collected errors; extra contains the collected errors that have no
+// counterpart in expected. Both results are nil when the sets match.
+//
+// Errors are compared with eq(collected, expected). If eq is nil, errors.Is is
+// used, so wrapped collected errors match their expected sentinels. Matching
+// has multiset semantics and is insensitive to order: each collected error is
+// paired with at most one expected error and vice versa. The pairing is a
+// maximum matching, so it is exact even when a collected error matches
+// several expected errors, as an error wrapping two sentinels does.
+//
+// CompareWith is primarily intended for tests:
+//
+//	missing, extra := c.CompareWith([]error{ErrA, ErrB}, nil)
+//	if len(missing) > 0 || len(extra) > 0 {
+//	    t.Fatalf("missing=%v extra=%v", missing, extra)
+//	}
+func (c *Collector) CompareWith(expected []error, eq func(a, b error) bool) (missing, extra []error) {
+	if eq == nil {
+		eq = errors.Is
+	}
+	return matchErrorSets(c.Errors(), expected, eq)
+}
+
+// matchErrorSets pairs the elements of got with those of want for which
+// eq(got, want) holds, maximizing the number of pairs, and returns the
+// unpaired elements of both. It finds augmenting paths (Kuhn's algorithm),
+// which is fast for the small sets compared in practice.
+func matchErrorSets(got, want []error, eq func(a, b error) bool) (missing, extra []error) {
+	adj := make([][]int, len(got))
+	for i, g := range got {
+		for j, w := range want {
+			if eq(g, w) {
+				adj[i] = append(adj[i], j)
+			}
+		}
+	}
+
+	owner := make([]int, len(want)) // index in got paired with want[j], or -1
+	for j := range owner {
+		owner[j] = -1
+	}
+	var augment func(i int, seen []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for _, j := range adj[i] {
+			if seen[j] {
+				continue
+			}
+			seen[j] = true
+			if owner[j] < 0 || augment(owner[j], seen) {
+				owner[j] = i
+				return true
+			}
+		}
+		return false
+	}
+
+	paired := make([]bool, len(got))
+	for i := range got {
+		paired[i] = augment(i, make([]bool, len(want)))
+	}
+
+	for i, g := range got {
+		if !paired[i] {
+			extra = append(extra, g)
+		}
+	}
+	for j, w := range want {
+		if owner[j] < 0 {
+			missing = append(missing, w)
+		}
+	}
+	return missing, extra
+}
+
+// CompareMessages is like CompareWith but compares errors by their Error()
+// strings.
+//
+// missingMsgs contains the expected messages that were not collected;
+// extraMsgs contains the messages of collected errors that were not expected.
+// Both results are nil when the message multisets are equal.
+func (c *Collector) CompareMessages(expected []string) (missingMsgs, extraMsgs []string) {
+	remaining := make(map[string]int, len(expected))
+	for _, msg := range expected {
+		remaining[msg]++
+	}
+
+	for _, err := range c.Errors() {
+		msg := err.Error()
+		if remaining[msg] > 0 {
+			remaining[msg]--
+			continue
+		}
+		extraMsgs = append(extraMsgs, msg)
+	}
+
+	for _, msg := range expected {
+		if remaining[msg] > 0 {
+			remaining[msg]--
+			missingMsgs = append(missingMsgs, msg)
+		}
+	}
+	return missingMsgs, extraMsgs
+}
+
+// Equal reports whether c and other contain the same errors in the same
+// order.
+//
+// The collectors are equal if they have the same Len and, pairwise by index
+// in Errors order, every error of c matches the corresponding error of other
+// according to errors.Is(a, b). Note that errors.Is is not symmetric: a
+// wrapped error of c matches a sentinel of other, but not the other way
+// round.
+//
+// Equal is primarily intended for tests and table fixtures; see also
+// CompareWith for an order-insensitive comparison that reports differences.
+func (c *Collector) Equal(other *Collector) bool {
+	return c.Len() == other.Len() && equalErrors(c.Errors(), other.Errors(), errors.Is)
+}
+
+// EqualMessages is like Equal but compares errors by their Error() strings.
+func (c *Collector) EqualMessages(other *Collector) bool {
+	return c.Len() == other.Len() && equalErrors(c.Errors(), other.Errors(), sameMessage)
+}
+
+// EqualErrors reports whether a and b consist of the same errors in the same
+// order, with the semantics of Collector.Equal.
+//
+// Both errors are expanded with Errors, so a multi-error equals another
+// multi-error with matching constituents, and a single error equals a
+// matching single error. Two nil errors are equal; a nil error is not equal
+// to a non-nil one.
+func EqualErrors(a, b error) bool {
+	return equalErrors(Errors(a), Errors(b), errors.Is)
+}
+
+// equalErrors reports whether a and b have the same length and eq holds for
+// every pair of errors at the same index.
+func equalErrors(a, b []error, eq func(a, b error) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !eq(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameMessage reports whether a and b have the same Error() string.
+func sameMessage(a, b error) bool {
+	return a.Error() == b.Error()
+}
+
+// EqualIgnoring reports whether a and b consist of the same messages after
+// normalization, regardless of order.
+//
+// The message of every constituent of a and b (see Errors) is passed through
+// ignore, which typically strips volatile parts such as timestamps or
+// addresses, and the resulting multisets are compared. This makes it suitable
+// for comparing the outcome of two runs of a flaky test:
+//
+//	stripTime := func(msg string) string {
+//	    return timestampPattern.ReplaceAllString(msg, "<ts>")
+//	}
+//	if !rxmerr.EqualIgnoring(run1, run2, stripTime) {
+//	    t.Errorf("runs failed differently:\n%s\n%s", rxmerr.Format(run1), rxmerr.Format(run2))
+//	}
+//
+// If ignore is nil, messages are compared unchanged. Two nil errors are
+// equal.
+func EqualIgnoring(a, b error, ignore func(string) string) bool {
+	return slices.Equal(normalizedMessages(a, ignore), normalizedMessages(b, ignore))
+}
+
+// normalizedMessages returns the sorted messages of the constituents of err,
+// each passed through normalize if it is not nil.
+func normalizedMessages(err error, normalize func(string) string) []string {
+	msgs := Messages(err)
+	if normalize != nil {
+		for i, msg := range msgs {
+			msgs[i] = normalize(msg)
+		}
+	}
+	slices.Sort(msgs)
+	return msgs
+}
+
+// MatchesExactly reports whether the constituents of err can be paired
+// one-to-one with targets such that each constituent matches its target
+// according to errors.Is.
+//
+// Matching has multiset semantics and is insensitive to order: a target
+// listed twice requires two matching constituents, and every constituent must
+// be accounted for. The pairing is a maximum matching, so it is found even
+// when a constituent matches several targets. This is what contract tests
+// asserting "fails with precisely these sentinels" need:
+//
+//	if !rxmerr.MatchesExactly(err, ErrNotFound, ErrNotFound, ErrForbidden) {
+//	    t.Fatal(rxmerr.ExplainMismatch(err, ErrNotFound, ErrNotFound, ErrForbidden))
+//	}
+//
+// A nil err matches exactly an empty list of targets.
+func MatchesExactly(err error, targets ...error) bool {
+	missing, extra := matchErrorSets(Errors(err), targets, errors.Is)
+	return len(missing) == 0 && len(extra) == 0
+}
+
+// ExplainMismatch describes why MatchesExactly(err, targets...) fails, listing
+// the constituents of err that matched no target and the targets that no
+// constituent matched:
+//
+//	unexpected errors:
+//	  - dial tcp 10.0.0.7:443: i/o timeout
+//	missing targets:
+//	  - forbidden
+//
+// If MatchesExactly reports true, ExplainMismatch returns an empty string.
+func ExplainMismatch(err error, targets ...error) string {
+	missing, extra := matchErrorSets(Errors(err), targets, errors.Is)
+
+	var b strings.Builder
+	writeList := func(title string, errs []error) {
+		if len(errs) == 0 {
+			return
+		}
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(title + ":")
+		for _, e := range errs {
+			b.WriteString("\n  - " + strings.ReplaceAll(e.Error(), "\n", "\n    "))
+		}
+	}
+	writeList("unexpected errors", extra)
+	writeList("missing targets", missing)
+	return b.String()
+}
+
+// ContainsExactly reports whether the messages of the constituents of err
+// (see Errors) are exactly messages, regardless of order.
+//
+// Messages are compared as multisets: a message listed twice requires two
+// constituents with that message, and every constituent must be listed. This
+// is the message-based counterpart of MatchesExactly, for strict assertions
+// on errors whose identity is not available:
+//
+//	if !rxmerr.ContainsExactly(err, "missing name", "missing email") {
+//	    t.Errorf("unexpected errors:\n%s", rxmerr.Format(err))
+//	}
+//
+// A nil err contains exactly an empty list of messages.
+func ContainsExactly(err error, messages ...string) bool {
+	want := slices.Clone(messages)
+	slices.Sort(want)
+	return slices.Equal(normalizedMessages(err, nil), want)
+}
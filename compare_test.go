@@ -0,0 +1,249 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"regexp"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestCompareWith(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(fmt.Errorf("open: %w", fs.ErrNotExist), io.EOF, io.EOF)
+
+	tests := []struct {
+		name        string
+		expected    []error
+		wantMissing []error
+		wantExtra   []error
+	}{
+		{
+			name:     "exact match in any order",
+			expected: []error{io.EOF, fs.ErrNotExist, io.EOF},
+		},
+		{
+			name:        "missing expected error",
+			expected:    []error{io.EOF, io.EOF, fs.ErrNotExist, fs.ErrPermission},
+			wantMissing: []error{fs.ErrPermission},
+		},
+		{
+			name:      "extra collected error",
+			expected:  []error{fs.ErrNotExist, io.EOF},
+			wantExtra: []error{io.EOF},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missing, extra := c.CompareWith(tt.expected, nil)
+			if !slices.Equal(missing, tt.wantMissing) {
+				t.Errorf("missing = %v, want %v", missing, tt.wantMissing)
+			}
+			if !slices.Equal(extra, tt.wantExtra) {
+				t.Errorf("extra = %v, want %v", extra, tt.wantExtra)
+			}
+		})
+	}
+}
+
+func TestCompareWithMaximumMatching(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+	both := errors.Join(errA, errB)
+
+	// A greedy pairing would match both to errA and report errA missing.
+	c := rxmerr.NewCollector()
+	c.Append(both)
+	c.Append(fmt.Errorf("wrapped: %w", errA))
+
+	missing, extra := c.CompareWith([]error{errA, errB}, nil)
+	if missing != nil || extra != nil {
+		t.Errorf("CompareWith() = (%v, %v), want an exact match", missing, extra)
+	}
+}
+
+func TestCompareWithCustomEq(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(errors.New("timeout after 3s"))
+
+	sameMessage := func(a, b error) bool { return a.Error() == b.Error() }
+	missing, extra := c.CompareWith([]error{errors.New("timeout after 3s")}, sameMessage)
+	if missing != nil || extra != nil {
+		t.Errorf("CompareWith() = (%v, %v), want an exact match", missing, extra)
+	}
+}
+
+func TestCompareMessages(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(errors.New("a"), errors.New("b"), errors.New("b"))
+
+	missing, extra := c.CompareMessages([]string{"b", "c", "a"})
+	if !slices.Equal(missing, []string{"c"}) {
+		t.Errorf("missing = %q, want [c]", missing)
+	}
+	if !slices.Equal(extra, []string{"b"}) {
+		t.Errorf("extra = %q, want [b]", extra)
+	}
+
+	missing, extra = c.CompareMessages([]string{"b", "a", "b"})
+	if missing != nil || extra != nil {
+		t.Errorf("CompareMessages() = (%q, %q), want an exact match", missing, extra)
+	}
+}
+
+func TestEqualIgnoring(t *testing.T) {
+	stamp := regexp.MustCompile(`\d{2}:\d{2}:\d{2}`)
+	stripTime := func(msg string) string {
+		return stamp.ReplaceAllString(msg, "<ts>")
+	}
+	run1 := rxmerr.Combine(
+		errors.New("12:00:01 dial backend: timeout"),
+		errors.New("12:00:02 read config: EOF"),
+	)
+	run2 := rxmerr.Combine(
+		errors.New("13:45:10 read config: EOF"),
+		errors.New("13:45:09 dial backend: timeout"),
+	)
+
+	tests := []struct {
+		name   string
+		a, b   error
+		ignore func(string) string
+		want   bool
+	}{
+		{"both nil", nil, nil, nil, true},
+		{"nil and error", nil, run1, stripTime, false},
+		{"timestamps stripped", run1, run2, stripTime, true},
+		{"timestamps kept", run1, run2, nil, false},
+		{"same raw", run1, run1, nil, true},
+		{"missing constituent", run1, errors.New("13:45:10 read config: EOF"), stripTime, false},
+		{
+			"multiset",
+			rxmerr.Combine(errors.New("01:00:00 x"), errors.New("02:00:00 x")),
+			rxmerr.Combine(errors.New("03:00:00 x"), errors.New("04:00:00 y")),
+			stripTime,
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rxmerr.EqualIgnoring(tt.a, tt.b, tt.ignore); got != tt.want {
+				t.Errorf("EqualIgnoring() = %v, want %v", got, tt.want)
+			}
+			if got := rxmerr.EqualIgnoring(tt.b, tt.a, tt.ignore); got != tt.want {
+				t.Errorf("EqualIgnoring() with swapped arguments = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesExactly(t *testing.T) {
+	errNotFound := errors.New("not found")
+	errForbidden := errors.New("forbidden")
+	// errGone matches both itself and errNotFound.
+	errGone := fmt.Errorf("gone: %w", errNotFound)
+
+	tests := []struct {
+		name    string
+		err     error
+		targets []error
+		want    bool
+	}{
+		{"nil and no targets", nil, nil, true},
+		{"nil and targets", nil, []error{errNotFound}, false},
+		{"no targets", errNotFound, nil, false},
+		{"exact", rxmerr.Combine(errNotFound, errForbidden), []error{errNotFound, errForbidden}, true},
+		{"order-insensitive", rxmerr.Combine(errForbidden, errNotFound), []error{errNotFound, errForbidden}, true},
+		{"wrapped", rxmerr.Combine(fmt.Errorf("get: %w", errNotFound), errForbidden), []error{errForbidden, errNotFound}, true},
+		{"duplicate targets", rxmerr.Combine(errNotFound, errNotFound), []error{errNotFound, errNotFound}, true},
+		{"duplicate target unmet", errNotFound, []error{errNotFound, errNotFound}, false},
+		{"duplicate constituent extra", rxmerr.Combine(errNotFound, errNotFound), []error{errNotFound}, false},
+		{"extra constituent", rxmerr.Combine(errNotFound, io.EOF), []error{errNotFound}, false},
+		{"maximum matching", rxmerr.Combine(errGone, errNotFound), []error{errNotFound, errGone}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rxmerr.MatchesExactly(tt.err, tt.targets...); got != tt.want {
+				t.Errorf("MatchesExactly() = %v, want %v", got, tt.want)
+			}
+			if got := rxmerr.ExplainMismatch(tt.err, tt.targets...); (got == "") != tt.want {
+				t.Errorf("ExplainMismatch() = %q, want empty iff MatchesExactly", got)
+			}
+		})
+	}
+}
+
+func TestExplainMismatch(t *testing.T) {
+	errNotFound := errors.New("not found")
+	errForbidden := errors.New("forbidden")
+	err := rxmerr.Combine(errNotFound, errors.New("dial: i/o\ntimeout"))
+
+	got := rxmerr.ExplainMismatch(err, errNotFound, errForbidden)
+	want := "unexpected errors:\n  - dial: i/o\n    timeout\nmissing targets:\n  - forbidden"
+	if got != want {
+		t.Errorf("ExplainMismatch() = %q, want %q", got, want)
+	}
+
+	if got, want := rxmerr.ExplainMismatch(errNotFound), "unexpected errors:\n  - not found"; got != want {
+		t.Errorf("ExplainMismatch() without targets = %q, want %q", got, want)
+	}
+	if got, want := rxmerr.ExplainMismatch(nil, errForbidden), "missing targets:\n  - forbidden"; got != want {
+		t.Errorf("ExplainMismatch(nil) = %q, want %q", got, want)
+	}
+}
+
+func TestContainsExactly(t *testing.T) {
+	err := rxmerr.Combine(errors.New("missing name"), errors.New("missing email"), errors.New("missing name"))
+
+	tests := []struct {
+		name     string
+		err      error
+		messages []string
+		want     bool
+	}{
+		{"nil and no messages", nil, nil, true},
+		{"nil and messages", nil, []string{"missing name"}, false},
+		{"exact", err, []string{"missing name", "missing email", "missing name"}, true},
+		{"any order", err, []string{"missing name", "missing name", "missing email"}, true},
+		{"missing message", err, []string{"missing name", "missing email"}, false},
+		{"extra message", err, []string{"missing name", "missing email", "missing name", "missing phone"}, false},
+		{"different message", err, []string{"missing name", "missing phone", "missing name"}, false},
+		{"single", io.EOF, []string{"EOF"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rxmerr.ContainsExactly(tt.err, tt.messages...); got != tt.want {
+				t.Errorf("ContainsExactly(%v, %q) = %v, want %v", tt.err, tt.messages, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainsExactlyKeepsArguments(t *testing.T) {
+	messages := []string{"b", "a"}
+	rxmerr.ContainsExactly(rxmerr.Combine(errors.New("a"), errors.New("b")), messages...)
+	if want := []string{"b", "a"}; !slices.Equal(messages, want) {
+		t.Errorf("messages = %q after ContainsExactly, want %q", messages, want)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"context"
+	"sync"
+)
+
+// CollectUntil runs tasks concurrently and aggregates their errors, canceling
+// the remaining work as soon as a fatal error is observed.
+//
+// Each task receives a context derived from ctx. When a task returns a
+// non-nil error for which fatal returns true, that derived context is
+// canceled so that the other tasks can stop early. CollectUntil always waits
+// for all tasks to return and then returns the aggregate of every non-nil
+// error they produced, including errors caused by the cancellation itself
+// (such as context.Canceled) if tasks chose to report them:
+//
+//	err := rxmerr.CollectUntil(ctx, isFatal,
+//	    func(ctx context.Context) error { return probeA(ctx) },
+//	    func(ctx context.Context) error { return probeB(ctx) },
+//	)
+//
+// If fatal is nil, no error is considered fatal and all tasks run to
+// completion. Errors are aggregated in the order in which tasks finish, which
+// is nondeterministic. If no task fails, CollectUntil returns nil.
+func CollectUntil(ctx context.Context, fatal func(error) bool, tasks ...func(context.Context) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+		c  Collector
+	)
+	for _, task := range tasks {
+		wg.Go(func() {
+			err := task(ctx)
+			if err == nil {
+				return
+			}
+
+			mu.Lock()
+			c.Append(err)
+			mu.Unlock()
+
+			if fatal != nil && fatal(err) {
+				cancel()
+			}
+		})
+	}
+	wg.Wait()
+
+	return c.Err()
+}
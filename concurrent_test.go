@@ -0,0 +1,110 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestCollectUntilCancelsOnFatal(t *testing.T) {
+	errFatal := errors.New("fatal")
+	isFatal := func(err error) bool { return errors.Is(err, errFatal) }
+
+	// Each waiter reports the cancellation it observes; without it, the
+	// test would hang until the timeout.
+	waiter := func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Second):
+			return errors.New("not canceled")
+		}
+	}
+
+	err := rxmerr.CollectUntil(context.Background(), isFatal,
+		waiter,
+		func(context.Context) error { return errFatal },
+		waiter,
+	)
+
+	errs := rxmerr.Errors(err)
+	if len(errs) != 3 {
+		t.Fatalf("CollectUntil() = %v, want 3 errors", err)
+	}
+	if !errors.Is(err, errFatal) {
+		t.Errorf("errors.Is(err, errFatal) = false, want true")
+	}
+	canceled := 0
+	for _, e := range errs {
+		if errors.Is(e, context.Canceled) {
+			canceled++
+		}
+	}
+	if canceled != 2 {
+		t.Errorf("%d tasks observed cancellation, want 2: %v", canceled, err)
+	}
+}
+
+func TestCollectUntilNonFatal(t *testing.T) {
+	ran := make(chan struct{}, 3)
+	task := func(err error) func(context.Context) error {
+		return func(ctx context.Context) error {
+			ran <- struct{}{}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+	}
+
+	err := rxmerr.CollectUntil(context.Background(), nil,
+		task(io.EOF), task(nil), task(io.ErrClosedPipe))
+
+	if len(ran) != 3 {
+		t.Errorf("%d tasks ran, want 3", len(ran))
+	}
+	errs := rxmerr.Errors(err)
+	if len(errs) != 2 || !errors.Is(err, io.EOF) || !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("CollectUntil() = %v, want EOF and closed pipe", err)
+	}
+}
+
+func TestCollectUntilNoErrors(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	if err := rxmerr.CollectUntil(context.Background(), nil, ok, ok); err != nil {
+		t.Errorf("CollectUntil() = %v, want nil", err)
+	}
+	if err := rxmerr.CollectUntil(context.Background(), nil); err != nil {
+		t.Errorf("CollectUntil() without tasks = %v, want nil", err)
+	}
+}
+
+func TestCollectUntilParentCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := rxmerr.CollectUntil(ctx, nil, func(ctx context.Context) error { return ctx.Err() })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("CollectUntil() = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"context"
+	"fmt"
+)
+
+// AppendContext appends err unless ctx is already done.
+//
+// If ctx.Err() is nil, AppendContext behaves like Append. Otherwise err is
+// dropped, and ctx.Err() is appended instead, but only the first time: once
+// the context has aborted the collection, ContextAborted reports true and
+// further errors appended through AppendContext are dropped. In a shutdown
+// sequence this yields exactly one context.DeadlineExceeded (or
+// context.Canceled) rather than a flood of follow-up errors such as "use of
+// closed network connection":
+//
+//	for _, r := range resources {
+//	    c.AppendContext(ctx, r.Shutdown(ctx))
+//	}
+//
+// If err is nil, AppendContext does nothing, even if ctx is done.
+func (c *Collector) AppendContext(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+	ctxErr := ctx.Err()
+	if ctxErr == nil {
+		c.Append(err)
+		return
+	}
+	if !c.ctxAborted {
+		c.ctxAborted = true
+		c.Append(ctxErr)
+	}
+}
+
+// ContextAborted reports whether AppendContext has dropped errors because its
+// context was done. Reset clears the flag.
+func (c *Collector) ContextAborted() bool {
+	return c.ctxAborted
+}
+
+// collectorKey is the context key under which ContextWithCollector stores a
+// collector.
+type collectorKey struct{}
+
+// ContextWithCollector returns a copy of ctx that carries c, so that code
+// deep in a call stack can reach the collector of the current request with
+// CollectorFromContext instead of having it passed through every function:
+//
+//	c := rxmerr.NewCollector(rxmerr.WithContextStamp(requestID))
+//	ctx = rxmerr.ContextWithCollector(ctx, c)
+//	handle(ctx)
+//	return c.Err()
+//
+// Storing a collector in a context is the usual pattern for request-scoped
+// values. The opposite, storing a context in a struct, is deliberately
+// avoided: a context describes a single call, and a collector that retained
+// one would apply its values and cancellation to appends made on behalf of
+// other calls. AppendCtx therefore reads the context it is given at append
+// time. (WithContext is the one exception, limited to log output of a
+// collector whose lifetime matches its context.)
+//
+// As Collector is not safe for concurrent use, goroutines started while
+// handling the request MUST NOT append to the collector from the context
+// without synchronization.
+func ContextWithCollector(ctx context.Context, c *Collector) context.Context {
+	return context.WithValue(ctx, collectorKey{}, c)
+}
+
+// AppendToContext returns a copy of ctx that carries c. It is equivalent to
+// ContextWithCollector(ctx, c).
+func (c *Collector) AppendToContext(ctx context.Context) context.Context {
+	return ContextWithCollector(ctx, c)
+}
+
+// CollectorFromContext returns the collector stored in ctx with
+// ContextWithCollector or AppendToContext, and reports whether one was
+// found.
+//
+// If ctx carries no collector, CollectorFromContext returns (nil, false).
+// Callers that want to append unconditionally can use AppendToCtx, or fall
+// back to a collector of their own:
+//
+//	c, ok := rxmerr.CollectorFromContext(ctx)
+//	if !ok {
+//	    c = rxmerr.NewCollector()
+//	}
+func CollectorFromContext(ctx context.Context) (*Collector, bool) {
+	c, ok := ctx.Value(collectorKey{}).(*Collector)
+	return c, ok && c != nil
+}
+
+// AppendToCtx appends err to the collector carried by ctx, which lets library
+// code report errors to a request-scoped collector without taking a
+// *Collector parameter:
+//
+//	func warmCache(ctx context.Context) {
+//	    for _, key := range keys {
+//	        rxmerr.AppendToCtx(ctx, cache.Load(key))
+//	    }
+//	}
+//
+// It reports whether ctx carries a collector; if it does not, err is
+// discarded. The error is passed to Append as is; use the AppendCtx method
+// to stamp it with values from ctx.
+func AppendToCtx(ctx context.Context, err error) bool {
+	c, ok := CollectorFromContext(ctx)
+	if ok {
+		c.Append(err)
+	}
+	return ok
+}
+
+// WithContextStamp returns an option that makes AppendCtx annotate errors
+// with a stamp extracted from the context passed to it, such as a request
+// ID:
+//
+//	c := rxmerr.NewCollector(rxmerr.WithContextStamp(func(ctx context.Context) string {
+//	    id, _ := ctx.Value(requestIDKey{}).(string)
+//	    return "req=" + id
+//	}))
+//	c.AppendCtx(ctx, err) // "req=abc123: <err>"
+//
+// If stamp returns an empty string, the error is appended unchanged. The
+// stamp only affects AppendCtx, and replaces its default of tagging errors
+// with the value of RequestIDKey; other Append methods are not changed.
+func WithContextStamp(stamp func(ctx context.Context) string) Option {
+	return func(c *Collector) {
+		c.stamp = stamp
+	}
+}
+
+// requestIDKey is the type of RequestIDKey.
+type requestIDKey struct{}
+
+// String returns the name under which the key is logged, see WithContextKeys.
+func (requestIDKey) String() string {
+	return "request_id"
+}
+
+// RequestIDKey is a well-known context key for the ID of the request being
+// served. Routers and middleware MAY store the ID under this key so that
+// AppendCtx tags errors with it without further configuration:
+//
+//	ctx = context.WithValue(ctx, rxmerr.RequestIDKey, "abc123")
+//	c.AppendCtx(ctx, err) // "req=abc123: <err>"
+//
+// The value SHOULD be a string; other values are rendered with fmt.Sprint.
+var RequestIDKey = requestIDKey{}
+
+// AppendCtx appends err annotated with a stamp extracted from ctx, as
+// fmt.Errorf("%s: %w", stamp, err). The original error remains reachable via
+// errors.Is and errors.As.
+//
+// The stamp is computed by the function set with WithContextStamp. Without
+// that option, the stamp is "req=<id>" if ctx carries a request ID under
+// RequestIDKey, and AppendCtx behaves like Append otherwise. An empty stamp
+// leaves err unchanged. Unlike AppendContext, AppendCtx does not check
+// whether ctx is done. A nil err is ignored.
+func (c *Collector) AppendCtx(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+	stamp := requestIDStamp
+	if c.stamp != nil {
+		stamp = c.stamp
+	}
+	if s := stamp(ctx); s != "" {
+		err = fmt.Errorf("%s: %w", s, err)
+	}
+	c.Append(err)
+}
+
+// requestIDStamp is the stamp used by AppendCtx without WithContextStamp.
+func requestIDStamp(ctx context.Context) string {
+	id := ctx.Value(RequestIDKey)
+	if id == nil {
+		return ""
+	}
+	if s := fmt.Sprint(id); s != "" {
+		return "req=" + s
+	}
+	return ""
+}
@@ -0,0 +1,223 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+// tenantKey is a context key used by the stamp tests.
+type tenantKey struct{}
+
+// tenantStamp stamps errors with the tenant carried by ctx.
+func tenantStamp(ctx context.Context) string {
+	if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+		return "tenant=" + tenant
+	}
+	return ""
+}
+
+func TestAppendContext(t *testing.T) {
+	errA := errors.New("a")
+	c := rxmerr.NewCollector()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c.AppendContext(ctx, errA)
+	c.AppendContext(ctx, nil)
+	if c.ContextAborted() {
+		t.Error("ContextAborted() before cancel = true, want false")
+	}
+
+	cancel()
+	c.AppendContext(ctx, nil)
+	if c.ContextAborted() {
+		t.Error("ContextAborted() after appending nil = true, want false")
+	}
+	for range 5 {
+		c.AppendContext(ctx, io.ErrClosedPipe)
+	}
+	if !c.ContextAborted() {
+		t.Error("ContextAborted() after cancel = false, want true")
+	}
+	if got := c.Errors(); !slices.Equal(got, []error{errA, context.Canceled}) {
+		t.Errorf("Errors() = %v, want [a context canceled]", got)
+	}
+
+	c.Append(io.EOF)
+	if got := c.Len(); got != 3 {
+		t.Errorf("Len() after a direct Append = %d, want 3", got)
+	}
+}
+
+func TestAppendContextReset(t *testing.T) {
+	c := rxmerr.NewCollector()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c.AppendContext(ctx, io.EOF)
+	c.Reset()
+	if c.ContextAborted() {
+		t.Fatal("ContextAborted() after Reset = true, want false")
+	}
+
+	c.AppendContext(ctx, io.EOF)
+	c.AppendContext(ctx, io.EOF)
+	if got := c.Errors(); !slices.Equal(got, []error{context.Canceled}) {
+		t.Errorf("Errors() after Reset = %v, want [context canceled]", got)
+	}
+}
+
+func TestCollectorFromContext(t *testing.T) {
+	c := rxmerr.NewCollector()
+	ctx := rxmerr.ContextWithCollector(context.Background(), c)
+
+	if got, ok := rxmerr.CollectorFromContext(ctx); !ok || got != c {
+		t.Errorf("CollectorFromContext() = (%p, %v), want (%p, true)", got, ok, c)
+	}
+	child, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if got, ok := rxmerr.CollectorFromContext(child); !ok || got != c {
+		t.Errorf("CollectorFromContext(child) = (%p, %v), want (%p, true)", got, ok, c)
+	}
+}
+
+func TestCollectorFromContextMissing(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"no collector", context.Background()},
+		{"nil collector", rxmerr.ContextWithCollector(context.Background(), nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := rxmerr.CollectorFromContext(tt.ctx); ok || got != nil {
+				t.Errorf("CollectorFromContext() = (%p, %v), want (nil, false)", got, ok)
+			}
+		})
+	}
+}
+
+func TestAppendToContext(t *testing.T) {
+	c := rxmerr.NewCollector()
+	ctx := c.AppendToContext(context.Background())
+
+	if got, ok := rxmerr.CollectorFromContext(ctx); !ok || got != c {
+		t.Errorf("CollectorFromContext() = (%p, %v), want (%p, true)", got, ok, c)
+	}
+
+	inner := rxmerr.NewCollector()
+	shadowed := inner.AppendToContext(ctx)
+	if got, _ := rxmerr.CollectorFromContext(shadowed); got != inner {
+		t.Errorf("CollectorFromContext(shadowed) = %p, want the innermost collector %p", got, inner)
+	}
+	if got, _ := rxmerr.CollectorFromContext(ctx); got != c {
+		t.Errorf("CollectorFromContext(parent) = %p, want %p", got, c)
+	}
+}
+
+func TestAppendToCtx(t *testing.T) {
+	errA := errors.New("a")
+	c := rxmerr.NewCollector()
+	ctx := c.AppendToContext(context.Background())
+
+	for _, err := range []error{errA, nil, io.EOF} {
+		if !rxmerr.AppendToCtx(ctx, err) {
+			t.Errorf("AppendToCtx(%v) = false, want true", err)
+		}
+	}
+	if got := c.Errors(); !slices.Equal(got, []error{errA, io.EOF}) {
+		t.Errorf("Errors() = %v, want [a EOF]", got)
+	}
+}
+
+func TestAppendToCtxMissing(t *testing.T) {
+	for _, ctx := range []context.Context{
+		context.Background(),
+		rxmerr.ContextWithCollector(context.Background(), nil),
+	} {
+		if rxmerr.AppendToCtx(ctx, io.EOF) {
+			t.Errorf("AppendToCtx() without a collector = true, want false")
+		}
+	}
+}
+
+func TestWithContextStamp(t *testing.T) {
+	c := rxmerr.NewCollector(rxmerr.WithContextStamp(tenantStamp))
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	ctx = context.WithValue(ctx, rxmerr.RequestIDKey, "abc123")
+
+	c.AppendCtx(ctx, io.EOF)
+	c.AppendCtx(context.Background(), io.ErrClosedPipe)
+	c.AppendCtx(ctx, nil)
+
+	want := []string{"tenant=acme: EOF", "io: read/write on closed pipe"}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	if err := c.Err(); !errors.Is(err, io.EOF) {
+		t.Errorf("errors.Is(Err(), io.EOF) = false for %v", err)
+	}
+}
+
+func TestWithContextStampOnlyAppendCtx(t *testing.T) {
+	c := rxmerr.NewCollector(rxmerr.WithContextStamp(tenantStamp))
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	ctx = rxmerr.ContextWithCollector(ctx, c)
+
+	c.Append(io.EOF)
+	rxmerr.AppendToCtx(ctx, io.ErrClosedPipe)
+
+	want := []string{"EOF", "io: read/write on closed pipe"}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q: only AppendCtx stamps errors", got, want)
+	}
+}
+
+func TestAppendCtxRequestID(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"without request ID", context.Background(), "EOF"},
+		{"with request ID", context.WithValue(context.Background(), rxmerr.RequestIDKey, "abc123"), "req=abc123: EOF"},
+		{"non-string request ID", context.WithValue(context.Background(), rxmerr.RequestIDKey, 42), "req=42: EOF"},
+		{"empty request ID", context.WithValue(context.Background(), rxmerr.RequestIDKey, ""), "EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := rxmerr.NewCollector()
+			c.AppendCtx(tt.ctx, io.EOF)
+			c.AppendCtx(tt.ctx, nil)
+
+			if got, want := c.Messages(), []string{tt.want}; !slices.Equal(got, want) {
+				t.Errorf("Messages() = %q, want %q", got, want)
+			}
+			if !errors.Is(c.Err(), io.EOF) {
+				t.Errorf("errors.Is(Err(), io.EOF) = false for %v", c.Err())
+			}
+		})
+	}
+}
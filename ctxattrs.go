@@ -0,0 +1,86 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// WithContext returns an option that associates ctx with the collector, so
+// that request-scoped values stored in it are included in the collector's
+// structured log output.
+//
+// The values are looked up with ctx.Value for the keys set with
+// WithContextKeys when the output is produced, by Log, LogAll, and LogValue.
+// Error messages themselves are not changed.
+func WithContext(ctx context.Context) Option {
+	return func(c *Collector) {
+		c.ctx = ctx
+	}
+}
+
+// WithContextKeys returns an option that selects the context keys whose
+// values are included in structured log output, see WithContext:
+//
+//	c := rxmerr.NewCollector(
+//	    rxmerr.WithContext(ctx),
+//	    rxmerr.WithContextKeys(traceIDKey{}, requestIDKey{}),
+//	)
+//	c.LogAll(logger.Error) // ... "count", 2, "trace_id", "4bf92f35...", ...
+//
+// Each value is logged under the name of its key, as rendered by fmt.Sprint;
+// a key type SHOULD therefore implement fmt.Stringer if its default rendering
+// is not a useful name. Keys for which ctx holds no value are omitted.
+// Multiple WithContextKeys options are combined.
+func WithContextKeys(keys ...any) Option {
+	return func(c *Collector) {
+		c.ctxKeys = append(c.ctxKeys, keys...)
+	}
+}
+
+// contextArgs returns the configured context values as alternating key-value
+// arguments, in the calling convention of log/slog.
+func (c *Collector) contextArgs() []any {
+	if c.ctx == nil {
+		return nil
+	}
+	var args []any
+	for _, key := range c.ctxKeys {
+		if v := c.ctx.Value(key); v != nil {
+			args = append(args, fmt.Sprint(key), v)
+		}
+	}
+	return args
+}
+
+// LogValue implements slog.LogValuer, so a collector can be logged directly:
+//
+//	logger.Error("sync failed", "errors", c)
+//
+// The value is a group with the number of collected errors ("count"), their
+// messages ("errors"), and the context values selected with
+// WithContextKeys.
+func (c *Collector) LogValue() slog.Value {
+	attrs := []slog.Attr{slog.Int("count", c.Len()), slog.Any("errors", c.Messages())}
+	args := c.contextArgs()
+	for i := 0; i < len(args); i += 2 {
+		attrs = append(attrs, slog.Any(args[i].(string), args[i+1]))
+	}
+	return slog.GroupValue(attrs...)
+}
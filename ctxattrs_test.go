@@ -0,0 +1,151 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+// requestIDKey is a context key logged as "request_id".
+type requestIDKey struct{}
+
+func (requestIDKey) String() string { return "request_id" }
+
+func TestWithContextKeys(t *testing.T) {
+	ctx := context.WithValue(context.Background(), traceIDKey{}, "4bf92f35")
+	ctx = context.WithValue(ctx, requestIDKey{}, 42)
+
+	tests := []struct {
+		name string
+		opts []rxmerr.Option
+		want []any
+	}{
+		{
+			name: "no context",
+			opts: []rxmerr.Option{rxmerr.WithContextKeys(traceIDKey{})},
+			want: []any{"count", 1},
+		},
+		{
+			name: "no keys",
+			opts: []rxmerr.Option{rxmerr.WithContext(ctx)},
+			want: []any{"count", 1},
+		},
+		{
+			name: "combined keys in order",
+			opts: []rxmerr.Option{
+				rxmerr.WithContext(ctx),
+				rxmerr.WithContextKeys(requestIDKey{}),
+				rxmerr.WithContextKeys(traceIDKey{}),
+			},
+			want: []any{"count", 1, "request_id", 42, "trace_id", "4bf92f35"},
+		},
+		{
+			name: "missing value omitted",
+			opts: []rxmerr.Option{rxmerr.WithContext(ctx), rxmerr.WithContextKeys(tenantKey{}, traceIDKey{})},
+			want: []any{"count", 1, "trace_id", "4bf92f35"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := rxmerr.NewCollector(tt.opts...)
+			c.Append(io.EOF)
+
+			var msgs []string
+			var got []any
+			c.LogAll(func(msg string, args ...any) {
+				msgs = append(msgs, msg)
+				got = args
+			})
+			if len(msgs) != 1 || msgs[0] != c.Report() {
+				t.Errorf("LogAll() messages = %q, want the report alone", msgs)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("LogAll() args = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithContextDoesNotChangeMessages(t *testing.T) {
+	ctx := context.WithValue(context.Background(), traceIDKey{}, "4bf92f35")
+	c := rxmerr.NewCollector(rxmerr.WithContext(ctx), rxmerr.WithContextKeys(traceIDKey{}))
+	c.Append(io.EOF)
+
+	if got := c.Err(); got != io.EOF {
+		t.Errorf("Err() = %v, want io.EOF unchanged", got)
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	ctx := context.WithValue(context.Background(), traceIDKey{}, "4bf92f35")
+	c := rxmerr.NewCollector(rxmerr.WithContext(ctx), rxmerr.WithContextKeys(traceIDKey{}))
+	c.Append(errors.New("a"), io.EOF)
+
+	v := c.LogValue()
+	if v.Kind() != slog.KindGroup {
+		t.Fatalf("LogValue().Kind() = %v, want Group", v.Kind())
+	}
+	var keys []string
+	values := map[string]slog.Value{}
+	for _, a := range v.Group() {
+		keys = append(keys, a.Key)
+		values[a.Key] = a.Value
+	}
+	if want := []string{"count", "errors", "trace_id"}; !slices.Equal(keys, want) {
+		t.Fatalf("LogValue() keys = %q, want %q", keys, want)
+	}
+	if got := values["count"].Int64(); got != 2 {
+		t.Errorf("count = %d, want 2", got)
+	}
+	if got, ok := values["errors"].Any().([]string); !ok || !slices.Equal(got, []string{"a", "EOF"}) {
+		t.Errorf("errors = %v, want [a EOF]", values["errors"].Any())
+	}
+	if got := values["trace_id"].String(); got != "4bf92f35" {
+		t.Errorf("trace_id = %q, want 4bf92f35", got)
+	}
+
+	var records []logRecord
+	newRecordingLogger(&records).Error("sync failed", "errors", c)
+	if len(records) != 1 {
+		t.Fatalf("logger produced %d records, want 1", len(records))
+	}
+	group, ok := records[0].attrs["errors"].([]slog.Attr)
+	if !ok || len(group) != 3 || group[0].Key != "count" {
+		t.Errorf("logged attr errors = %#v, want the LogValue group", records[0].attrs["errors"])
+	}
+}
+
+func TestLogValueEmpty(t *testing.T) {
+	group := rxmerr.NewCollector().LogValue().Group()
+	if len(group) != 2 {
+		t.Fatalf("LogValue() on empty collector = %v, want count and errors only", group)
+	}
+	if got := group[0].Value.Int64(); got != 0 {
+		t.Errorf("count = %d, want 0", got)
+	}
+	if got, _ := group[1].Value.Any().([]string); len(got) != 0 {
+		t.Errorf("errors = %q, want none", got)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+)
+
+// CombineWithContext merges errs like Combine and attaches ctx, a map of
+// structured diagnostic data, to the result:
+//
+//	err := rxmerr.CombineWithContext(map[string]any{
+//	    "tenant": tenantID,
+//	    "batch":  batchNo,
+//	}, errs...)
+//	...
+//	logger.Error("import failed", "err", err, "ctx", rxmerr.ContextOf(err))
+//
+// The returned error implements Context() map[string]any, has the same
+// message as Combine(errs...), and exposes the same constituents via Errors,
+// errors.Is, and errors.As. The map is copied, so later changes to ctx do not
+// affect the result.
+//
+// If all errs are nil, CombineWithContext returns nil. If ctx is empty, it
+// returns Combine(errs...).
+func CombineWithContext(ctx map[string]any, errs ...error) error {
+	err := Combine(errs...)
+	if err == nil || len(ctx) == 0 {
+		return err
+	}
+	return &contextMapError{err: err, ctx: maps.Clone(ctx)}
+}
+
+// ContextOf returns the context attached to err by CombineWithContext, or by
+// any other error in its chain implementing Context() map[string]any, as
+// found by errors.As. It returns nil if there is none.
+//
+// The returned map is a copy owned by the caller.
+func ContextOf(err error) map[string]any {
+	var ce interface{ Context() map[string]any }
+	if errors.As(err, &ce) {
+		return ce.Context()
+	}
+	return nil
+}
+
+// contextMapError decorates an aggregate with a context map.
+type contextMapError struct {
+	err error
+	ctx map[string]any
+}
+
+func (e *contextMapError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the constituents of the decorated aggregate.
+func (e *contextMapError) Unwrap() []error {
+	return Errors(e.err)
+}
+
+// Context returns a copy of the attached context.
+func (e *contextMapError) Context() map[string]any {
+	return maps.Clone(e.ctx)
+}
+
+// Format implements fmt.Formatter: %+v lists the constituents, each formatted
+// with %+v, %q quotes Error(), and other verbs write Error().
+func (e *contextMapError) Format(f fmt.State, verb rune) {
+	formatAggregate(f, verb, e, e.Unwrap())
+}
@@ -0,0 +1,104 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestCombineWithContext(t *testing.T) {
+	errA := errors.New("a")
+	ctx := map[string]any{"tenant": "acme", "batch": 7}
+	err := rxmerr.CombineWithContext(ctx, errA, nil, io.EOF)
+
+	plain := rxmerr.Combine(errA, io.EOF)
+	if got, want := err.Error(), plain.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got := rxmerr.Errors(err); !slices.Equal(got, []error{errA, io.EOF}) {
+		t.Errorf("Errors() = %v, want [a EOF]", got)
+	}
+	if !errors.Is(err, errA) || !errors.Is(err, io.EOF) {
+		t.Errorf("CombineWithContext() = %v, constituents not reachable via errors.Is", err)
+	}
+	if got := rxmerr.ContextOf(err); !maps.Equal(got, ctx) {
+		t.Errorf("ContextOf() = %v, want %v", got, ctx)
+	}
+	if got, want := fmt.Sprintf("%+v", err), "2 errors occurred:\n  [0] a\n  [1] EOF"; got != want {
+		t.Errorf("%%+v = %q, want %q", got, want)
+	}
+}
+
+func TestCombineWithContextCopies(t *testing.T) {
+	ctx := map[string]any{"tenant": "acme"}
+	err := rxmerr.CombineWithContext(ctx, io.EOF)
+
+	ctx["tenant"] = "other"
+	got := rxmerr.ContextOf(err)
+	if got["tenant"] != "acme" {
+		t.Errorf("ContextOf() after changing the input map = %v, want tenant=acme", got)
+	}
+
+	got["tenant"] = "changed"
+	if got := rxmerr.ContextOf(err); got["tenant"] != "acme" {
+		t.Errorf("ContextOf() after changing a previous result = %v, want tenant=acme", got)
+	}
+}
+
+func TestCombineWithContextNoContext(t *testing.T) {
+	if err := rxmerr.CombineWithContext(map[string]any{"k": 1}, nil, nil); err != nil {
+		t.Errorf("CombineWithContext() with nil errors = %v, want nil", err)
+	}
+	if err := rxmerr.CombineWithContext(map[string]any{"k": 1}); err != nil {
+		t.Errorf("CombineWithContext() without errors = %v, want nil", err)
+	}
+	for _, ctx := range []map[string]any{nil, {}} {
+		if err := rxmerr.CombineWithContext(ctx, io.EOF); err != io.EOF {
+			t.Errorf("CombineWithContext(%v, io.EOF) = %#v, want io.EOF", ctx, err)
+		}
+	}
+}
+
+func TestContextOf(t *testing.T) {
+	ctx := map[string]any{"batch": 7}
+	tests := []struct {
+		name string
+		err  error
+		want map[string]any
+	}{
+		{name: "nil", err: nil},
+		{name: "plain", err: io.EOF},
+		{name: "direct", err: rxmerr.CombineWithContext(ctx, io.EOF), want: ctx},
+		{name: "wrapped", err: fmt.Errorf("import: %w", rxmerr.CombineWithContext(ctx, io.EOF)), want: ctx},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rxmerr.ContextOf(tt.err)
+			if (got == nil) != (tt.want == nil) || !maps.Equal(got, tt.want) {
+				t.Errorf("ContextOf(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
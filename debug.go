@@ -0,0 +1,64 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// debugHook holds the hook installed by SetDebugHook, or nil.
+var debugHook atomic.Pointer[func(err error, stack []byte)]
+
+// SetDebugHook installs fn as a process-wide interceptor for errors flowing
+// through this package. Passing nil disables the hook.
+//
+// While a hook is installed, it is called synchronously with every non-nil
+// error passed to Collector.Append (and the Collector methods built on it) and
+// to the AppendInto and AppendFunc functions, together with the stack trace of
+// the calling goroutine at collection time:
+//
+//	rxmerr.SetDebugHook(func(err error, stack []byte) {
+//	    log.Printf("collected %v at:\n%s", err, stack)
+//	})
+//	defer rxmerr.SetDebugHook(nil)
+//
+// The hook sees errors as they were passed in, before any Collector options
+// are applied.
+//
+// This is a debugging facility for tracking down where a mysterious error
+// enters an aggregate. It is off by default and SHOULD NOT be left enabled in
+// production steady state: capturing a stack for every collected error is
+// expensive. When disabled, the cost on the collection path is a single
+// atomic load.
+//
+// SetDebugHook is safe for concurrent use; fn itself MUST be safe for
+// concurrent use if errors are collected from multiple goroutines.
+func SetDebugHook(fn func(err error, stack []byte)) {
+	if fn == nil {
+		debugHook.Store(nil)
+		return
+	}
+	debugHook.Store(&fn)
+}
+
+// callDebugHook invokes the installed debug hook, if any, for err.
+func callDebugHook(err error) {
+	if hook := debugHook.Load(); hook != nil {
+		(*hook)(err, debug.Stack())
+	}
+}
@@ -0,0 +1,92 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestSetDebugHook(t *testing.T) {
+	var seen []error
+	var stacks []string
+	rxmerr.SetDebugHook(func(err error, stack []byte) {
+		seen = append(seen, err)
+		stacks = append(stacks, string(stack))
+	})
+	t.Cleanup(func() { rxmerr.SetDebugHook(nil) })
+
+	errA := errors.New("a")
+	c := rxmerr.NewCollector()
+	c.Append(errA, nil)
+
+	var err error
+	rxmerr.AppendInto(&err, io.EOF)
+	rxmerr.AppendInto(&err, nil)
+	rxmerr.AppendFunc(&err, func() error { return io.ErrClosedPipe })
+	rxmerr.AppendFunc(&err, func() error { return nil })
+
+	want := []error{errA, io.EOF, io.ErrClosedPipe}
+	if len(seen) != len(want) {
+		t.Fatalf("hook called %d times with %v, want %d", len(seen), seen, len(want))
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Errorf("hook call %d got %v, want %v", i, seen[i], want[i])
+		}
+		if !strings.Contains(stacks[i], "TestSetDebugHook") {
+			t.Errorf("hook call %d stack does not include the caller:\n%s", i, stacks[i])
+		}
+	}
+}
+
+func TestSetDebugHookNil(t *testing.T) {
+	calls := 0
+	rxmerr.SetDebugHook(func(error, []byte) { calls++ })
+	rxmerr.SetDebugHook(nil)
+
+	c := rxmerr.NewCollector()
+	c.Append(io.EOF)
+	var err error
+	rxmerr.AppendInto(&err, io.EOF)
+
+	if calls != 0 {
+		t.Errorf("disabled hook called %d times, want 0", calls)
+	}
+}
+
+func BenchmarkAppendNoDebugHook(b *testing.B) {
+	rxmerr.SetDebugHook(nil)
+	b.ReportAllocs()
+	for b.Loop() {
+		c := rxmerr.NewCollector()
+		c.Append(io.EOF)
+	}
+}
+
+func BenchmarkAppendIntoNoDebugHook(b *testing.B) {
+	rxmerr.SetDebugHook(nil)
+	b.ReportAllocs()
+	for b.Loop() {
+		var err error
+		rxmerr.AppendInto(&err, io.EOF)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import "errors"
+
+// ErrMaxDepth marks a branch of an error tree that CombineSafeDepth did not
+// descend into because it is nested deeper than the requested maximum.
+//
+// ErrMaxDepth does not wrap the truncated branch: errors.Is and errors.As
+// never reach it, so a cyclic branch cannot make them recurse forever.
+var ErrMaxDepth = errors.New("rxmerr: max depth reached")
+
+// CombineSafeDepth merges errs like Combine, flattening nested multi-errors
+// into a single level, and guards against unbounded recursion while doing
+// so.
+//
+// Every error implementing Unwrap() []error (multierr aggregates, errors.Join
+// results, fmt.Errorf with several %w verbs, and the aggregates of this
+// package) is replaced by its constituents, recursively, down to maxDepth
+// levels of nesting: the errs themselves are at depth 0, the constituents of
+// a multi-error among errs at depth 1, and so on. A multi-error found at
+// depth maxDepth is not expanded; ErrMaxDepth takes its place in the result.
+// A non-positive maxDepth therefore replaces every multi-error among errs by
+// the marker.
+//
+// This makes CombineSafeDepth suitable for defensive aggregation of error
+// graphs that may be self-referential, where naive flattening would overflow
+// the stack:
+//
+//	err := rxmerr.CombineSafeDepth(32, untrusted...)
+//	errors.Is(err, rxmerr.ErrMaxDepth) // true if a branch was truncated
+//
+// Errors with a single Unwrap() error are kept as they are, without
+// descending into their chain, so their messages are preserved. Nil errors
+// are skipped; if nothing remains, CombineSafeDepth returns nil.
+func CombineSafeDepth(maxDepth int, errs ...error) error {
+	var flat []error
+	for _, err := range errs {
+		flat = appendDepth(flat, err, 0, maxDepth)
+	}
+	return Combine(flat...)
+}
+
+// appendDepth appends err, found at the given depth, to dst, expanding
+// multi-errors until maxDepth is reached.
+func appendDepth(dst []error, err error, depth, maxDepth int) []error {
+	multi, ok := err.(interface{ Unwrap() []error })
+	switch {
+	case err == nil:
+		return dst
+	case !ok:
+		return append(dst, err)
+	case depth >= maxDepth:
+		return append(dst, ErrMaxDepth)
+	}
+	for _, inner := range multi.Unwrap() {
+		dst = appendDepth(dst, inner, depth+1, maxDepth)
+	}
+	return dst
+}
@@ -0,0 +1,90 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+// cyclicError is a multi-error whose constituents include itself.
+type cyclicError struct {
+	leaf error
+}
+
+func (e *cyclicError) Error() string {
+	return "cycle"
+}
+
+func (e *cyclicError) Unwrap() []error {
+	return []error{e, e.leaf}
+}
+
+func TestCombineSafeDepthCyclic(t *testing.T) {
+	cyc := &cyclicError{leaf: io.EOF}
+
+	for _, maxDepth := range []int{0, 1, 3, 64} {
+		t.Run(fmt.Sprint(maxDepth), func(t *testing.T) {
+			err := rxmerr.CombineSafeDepth(maxDepth, cyc)
+
+			want := []error{rxmerr.ErrMaxDepth}
+			for range maxDepth {
+				want = append(want, io.EOF)
+			}
+			if got := rxmerr.Errors(err); !slices.Equal(got, want) {
+				t.Errorf("Errors() = %v, want %v", got, want)
+			}
+			if !errors.Is(err, rxmerr.ErrMaxDepth) {
+				t.Errorf("errors.Is(%v, ErrMaxDepth) = false", err)
+			}
+		})
+	}
+}
+
+func TestCombineSafeDepth(t *testing.T) {
+	errA, errB, errC := errors.New("a"), errors.New("b"), errors.New("c")
+	nested := rxmerr.Combine(errA, errors.Join(errB, fmt.Errorf("%w, %w", errC, io.EOF)))
+	wrapped := fmt.Errorf("op: %w", rxmerr.Combine(errA, errB))
+
+	tests := []struct {
+		name     string
+		maxDepth int
+		errs     []error
+		want     []error
+	}{
+		{"nil", 3, []error{nil, nil}, nil},
+		{"leaves", 0, []error{errA, nil, errB}, []error{errA, errB}},
+		{"shallow", 0, []error{nested, errC}, []error{rxmerr.ErrMaxDepth, errC}},
+		{"depth 1", 1, []error{nested}, []error{errA, rxmerr.ErrMaxDepth}},
+		{"depth 2", 2, []error{nested}, []error{errA, errB, rxmerr.ErrMaxDepth}},
+		{"deep enough", 3, []error{nested}, []error{errA, errB, errC, io.EOF}},
+		{"single wrapper kept", 0, []error{wrapped}, []error{wrapped}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rxmerr.CombineSafeDepth(tt.maxDepth, tt.errs...)
+			if got := rxmerr.Errors(err); !slices.Equal(got, tt.want) {
+				t.Errorf("Errors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
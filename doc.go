@@ -73,6 +73,8 @@
 // Collector is intended for sequential use within a single goroutine. It is
 // NOT safe for concurrent use without external synchronization. For concurrent
 // workflows, callers SHOULD either:
+//   - use SafeCollector, which guards a smaller Collector-like API with a
+//     mutex;
 //   - maintain a separate Collector per goroutine and merge their Err()
 //     results at the end using Append or Combine, or
 //   - use their own concurrency-safe aggregation structure and delegate
@@ -85,7 +87,7 @@
 //   - Err() error returns the aggregated error (or nil if nothing was added);
 //   - Len() int and HasError() bool expose simple inspection helpers;
 //   - Reset() clears the accumulated state for reuse;
-//   - Errors() []error exposes all underlying errors via multierr.Errors.
+//   - Errors() []error returns a copy of the collected errors.
 //
 // # Free functions
 //
@@ -128,11 +130,14 @@
 //
 // Relationship to go.uber.org/multierr
 //
-// All aggregation semantics are delegated to go.uber.org/multierr. rxmerr
-// does not define its own ErrorGroup type or alternative multi-error
-// representation. Instead:
+// All aggregation semantics are delegated to go.uber.org/multierr. Apart from
+// MultiError, a small concrete type offered for callers that need a type to
+// assert to, rxmerr does not define its own ErrorGroup type or alternative
+// multi-error representation. Instead:
 //
-//   - Collector uses multierr.Append and multierr.Errors internally;
+//   - Collector stores the collected errors in a slice and builds its
+//     aggregate from them with multierr.Combine, or with JoinCombine if it
+//     was created with WithJoinBackend;
 //   - Combine, Append, Errors, AppendInto, and AppendFunc are thin wrappers
 //     around the corresponding multierr functions.
 //
@@ -145,13 +150,39 @@
 //   - changes in multierr semantics may affect the behavior of this package,
 //     although rxmerr strives to remain a stable, documented façade.
 //
+// # errors.Is and errors.As
+//
+// Every aggregate and wrapper produced by this package keeps its constituents
+// reachable through Unwrap() error or Unwrap() []error. As a result,
+// errors.Is and errors.As reach every leaf error regardless of how
+// aggregates are nested: multierr aggregates, errors.Join trees, fmt.Errorf
+// wrappers with one or more %w verbs, and the results of Combine,
+// CombineVerbose, Errorf, AppendInto, and Collector may be freely composed.
+//
+// The only helpers that do not preserve error identity are those that are
+// explicitly message-based, such as the rendering helpers (FormatBullet,
+// FormatClustered, ErrorsMap), the cross-process encoding of EncodeHeader,
+// and the protobuf conversion in the proto sub-package; their documentation
+// says so.
+//
+// # Optional integrations
+//
+// Integrations that would pull heavy dependencies into every importer are
+// compiled only when the corresponding build tag is set:
+//
+//   - rxmerr_grpc enables GRPCError, AppendGRPCStatus, the gRPC-aware
+//     Collector methods, and the metadata helpers SetErrorMetadata and
+//     ErrorFromMetadata.
+//   - rxmerr_expvar enables Collector.PublishExpvar.
+//   - rxmerr_otel enables Collector.RecordTo for OpenTelemetry spans.
+//
 // # Concurrency considerations
 //
 // None of the exported helpers in this package are inherently concurrency-safe
 // when used with shared mutable state:
 //
 //   - Collector instances MUST NOT be accessed concurrently without external
-//     synchronization;
+//     synchronization; SafeCollector MAY be;
 //   - the free functions (such as AppendInto and AppendFunc) are safe as long
 //     as the caller ensures that shared destination error variables are not
 //     mutated from multiple goroutines at the same time.
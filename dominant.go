@@ -0,0 +1,59 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+// Dominant returns the most frequent constituent of err together with the
+// number of times it occurred.
+//
+// Constituents are obtained via Errors and are grouped by their Error()
+// string, so distinct error values with identical messages are counted as the
+// same error. The returned error is the first constituent (in Errors order)
+// of the most frequent group; when several groups share the highest count,
+// the group whose first member appears earliest wins.
+//
+// The boolean result reports whether the dominant error represents a strict
+// majority of all constituents, i.e. it occurred more than half of the time:
+//
+//	err := rxmerr.Combine(errTimeout, errTimeout, errRefused)
+//	dom, n, majority := rxmerr.Dominant(err) // errTimeout, 2, true
+//
+// If err is nil, Dominant returns (nil, 0, false).
+func Dominant(err error) (error, int, bool) {
+	errs := Errors(err)
+	if len(errs) == 0 {
+		return nil, 0, false
+	}
+
+	msgs := make([]string, len(errs))
+	counts := make(map[string]int, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+		counts[msgs[i]]++
+	}
+
+	var (
+		best  error
+		bestN int
+	)
+	for i, e := range errs {
+		if n := counts[msgs[i]]; n > bestN {
+			best, bestN = e, n
+		}
+	}
+
+	return best, bestN, bestN*2 > len(errs)
+}
@@ -0,0 +1,105 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestDominant(t *testing.T) {
+	timeout := errors.New("i/o timeout")
+	refused := errors.New("connection refused")
+	reset := errors.New("connection reset")
+
+	tests := []struct {
+		name         string
+		err          error
+		wantMsg      string
+		wantN        int
+		wantMajority bool
+	}{
+		{
+			name:         "nil",
+			err:          nil,
+			wantMsg:      "",
+			wantN:        0,
+			wantMajority: false,
+		},
+		{
+			name:         "single",
+			err:          timeout,
+			wantMsg:      "i/o timeout",
+			wantN:        1,
+			wantMajority: true,
+		},
+		{
+			name:         "clear majority",
+			err:          rxmerr.Combine(timeout, refused, timeout, timeout, errors.New("i/o timeout")),
+			wantMsg:      "i/o timeout",
+			wantN:        4,
+			wantMajority: true,
+		},
+		{
+			name:         "tie picks earliest group",
+			err:          rxmerr.Combine(refused, timeout, timeout, refused),
+			wantMsg:      "connection refused",
+			wantN:        2,
+			wantMajority: false,
+		},
+		{
+			name:         "uniform distribution",
+			err:          rxmerr.Combine(timeout, refused, reset),
+			wantMsg:      "i/o timeout",
+			wantN:        1,
+			wantMajority: false,
+		},
+		{
+			name:         "plurality without majority",
+			err:          rxmerr.Combine(timeout, timeout, refused, reset),
+			wantMsg:      "i/o timeout",
+			wantN:        2,
+			wantMajority: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dom, n, majority := rxmerr.Dominant(tt.err)
+			gotMsg := ""
+			if dom != nil {
+				gotMsg = dom.Error()
+			}
+			if gotMsg != tt.wantMsg || n != tt.wantN || majority != tt.wantMajority {
+				t.Errorf("Dominant() = (%q, %d, %v), want (%q, %d, %v)",
+					gotMsg, n, majority, tt.wantMsg, tt.wantN, tt.wantMajority)
+			}
+		})
+	}
+}
+
+func TestDominantReturnsFirstMember(t *testing.T) {
+	first := errors.New("boom")
+	second := errors.New("boom")
+
+	dom, _, _ := rxmerr.Dominant(rxmerr.Combine(first, second))
+	if dom != first {
+		t.Errorf("Dominant() returned %p, want the first member %p", dom, first)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"fmt"
+	"slices"
+)
+
+// TruncateAt keeps only the first n collected errors and discards the rest.
+//
+// Indices count the collected errors in collection order. Every appended
+// error is one element, even if it is itself an aggregate such as the result
+// of errors.Join, and so is a run merged by WithCoalesce, which is kept or
+// discarded as a whole. If n is at least the number of collected errors,
+// TruncateAt is a no-op; a negative n is treated as zero, which discards all
+// errors. After truncation, Len returns the number of appends represented by
+// the remaining errors and the aggregate returned by Err reflects the change:
+//
+//	c.Append(errA)
+//	mark := c.Len()
+//	c.Append(errB)
+//	c.Append(errC)
+//	c.TruncateAt(mark) // c.Errors() == []error{errA}
+//
+// Unlike Reset, TruncateAt keeps the context message set with SetContext.
+// Error values previously returned by Err are not affected. TruncateAt is
+// destructive and intended for post-collection manipulation, for example by
+// test frameworks or interactive tools rolling back to a known state.
+func (c *Collector) TruncateAt(n int) {
+	errs := c.storedErrors()
+	if n >= len(errs) {
+		return
+	}
+	errs = errs[:max(n, 0)]
+	c.replaceAll(errs)
+	c.count = 0
+	for _, err := range errs {
+		c.count += appendsIn(err)
+	}
+}
+
+// TruncateLast removes the last n collected errors. See TruncateAt.
+//
+// If n is at least the number of collected errors, all errors are discarded.
+// A non-positive n is a no-op.
+func (c *Collector) TruncateLast(n int) {
+	if n <= 0 {
+		return
+	}
+	c.TruncateAt(len(c.storedErrors()) - n)
+}
+
+// SwapAt replaces the i-th collected error with newErr and returns the error
+// it replaced. If newErr is nil, the i-th error is removed instead, and Len
+// decreases by the number of appends it represented: one, unless it is a run
+// merged by WithCoalesce. A merged run replaced by newErr counts as a single
+// append.
+//
+// SwapAt is a testing API, for example for frameworks that mutate specific
+// errors to exercise reporting code; production code SHOULD NOT use it.
+//
+// Indices count the collected errors as for TruncateAt. newErr is stored
+// as-is, without passing through the options the collector was created with.
+// If i is out of range, SwapAt leaves the collector unchanged and returns a
+// non-nil err instead of panicking.
+func (c *Collector) SwapAt(i int, newErr error) (old, err error) {
+	errs := c.storedErrors()
+	if i < 0 || i >= len(errs) {
+		return nil, fmt.Errorf("rxmerr: swap index %d out of range [0:%d]", i, len(errs))
+	}
+
+	old = errs[i]
+	c.count -= appendsIn(old)
+	if newErr == nil {
+		errs = append(errs[:i], errs[i+1:]...)
+	} else {
+		errs[i] = newErr
+		c.count += appendsIn(newErr)
+	}
+	c.replaceAll(errs)
+	return old, nil
+}
+
+// storedErrors returns a copy of the stored errors with interned repeats
+// materialized (see Counted), but without notes added to the aggregate, such
+// as that of MaxRate. Stored errors that are themselves aggregates are kept
+// as single entries, so indices into the result address what was appended.
+func (c *Collector) storedErrors() []error {
+	return slices.Clone(c.expanded())
+}
+
+// replaceAll replaces the stored errors with errs, which are taken as already
+// prepared. Interning, merge state and size accounting are rebuilt for the
+// new contents; the count is left to the caller.
+func (c *Collector) replaceAll(errs []error) {
+	c.errs = nil
+	c.reps = nil
+	c.err = nil
+	c.run = coalesceRun{}
+	c.storedBytes = 0
+	for _, err := range errs {
+		c.storedBytes += messageBytes(err)
+		if !c.internInto(err) {
+			c.errs = append(c.errs, err)
+			if c.reps != nil {
+				c.reps = append(c.reps, 0)
+			}
+		}
+	}
+}
+
+// appendsIn returns the number of appends represented by err, a stored error:
+// the length of the run for an entry merged by WithCoalesce, and 1 otherwise.
+func appendsIn(err error) int {
+	if r, ok := err.(*repeatedError); ok {
+		return 1 + r.repeats
+	}
+	return 1
+}
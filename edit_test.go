@@ -0,0 +1,216 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestTruncateAt(t *testing.T) {
+	errA, errB, errC := errors.New("a"), errors.New("b"), errors.New("c")
+
+	tests := []struct {
+		n    int
+		want []error
+	}{
+		{n: -1, want: nil},
+		{n: 0, want: nil},
+		{n: 1, want: []error{errA}},
+		{n: 2, want: []error{errA, errB}},
+		{n: 3, want: []error{errA, errB, errC}},
+		{n: 10, want: []error{errA, errB, errC}},
+	}
+
+	for _, tt := range tests {
+		c := rxmerr.NewCollector()
+		c.Append(errA, errB, errC)
+		c.TruncateAt(tt.n)
+
+		if got := c.Errors(); !slices.Equal(got, tt.want) {
+			t.Errorf("TruncateAt(%d): Errors() = %v, want %v", tt.n, got, tt.want)
+		}
+		if got := c.Len(); got != len(tt.want) {
+			t.Errorf("TruncateAt(%d): Len() = %d, want %d", tt.n, got, len(tt.want))
+		}
+	}
+}
+
+func TestTruncateAtKeepsAggregateEntries(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	joined := errors.Join(errA, errB)
+
+	c := rxmerr.NewCollector()
+	c.Append(joined, io.EOF)
+	c.TruncateAt(1)
+
+	if got := c.Len(); got != 1 {
+		t.Errorf("Len() = %d, want 1", got)
+	}
+	if err := c.Err(); err != joined {
+		t.Errorf("Err() = %v, want the appended errors.Join result", err)
+	}
+
+	c.TruncateAt(5)
+	if got := c.Len(); got != 1 {
+		t.Errorf("Len() after no-op TruncateAt = %d, want 1", got)
+	}
+}
+
+func TestTruncateAtInterned(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(io.EOF, io.EOF, io.EOF, io.ErrClosedPipe)
+	c.TruncateAt(2)
+
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+	if got := c.Counted(); len(got) != 1 || got[0].N != 2 {
+		t.Errorf("Counted() = %v, want EOF interned twice", got)
+	}
+}
+
+func TestTruncateAtCoalesced(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.WithCoalesce(time.Minute, fakeClock(&now)))
+	c.Append(io.EOF, io.EOF, io.EOF, io.ErrClosedPipe, io.ErrClosedPipe)
+
+	c.TruncateAt(1)
+	if got := c.Len(); got != 3 {
+		t.Errorf("Len() = %d, want 3: the kept entry merges three appends", got)
+	}
+	if got := c.Stored(); got != 1 {
+		t.Errorf("Stored() = %d, want 1", got)
+	}
+}
+
+func TestTruncateLast(t *testing.T) {
+	errA, errB, errC := errors.New("a"), errors.New("b"), errors.New("c")
+
+	tests := []struct {
+		n    int
+		want []error
+	}{
+		{n: -1, want: []error{errA, errB, errC}},
+		{n: 0, want: []error{errA, errB, errC}},
+		{n: 1, want: []error{errA, errB}},
+		{n: 3, want: nil},
+		{n: 10, want: nil},
+	}
+
+	for _, tt := range tests {
+		c := rxmerr.NewCollector()
+		c.Append(errA, errB, errC)
+		c.TruncateLast(tt.n)
+
+		if got := c.Errors(); !slices.Equal(got, tt.want) {
+			t.Errorf("TruncateLast(%d): Errors() = %v, want %v", tt.n, got, tt.want)
+		}
+		if got := c.Len(); got != len(tt.want) {
+			t.Errorf("TruncateLast(%d): Len() = %d, want %d", tt.n, got, len(tt.want))
+		}
+	}
+}
+
+func TestSwapAt(t *testing.T) {
+	errA, errB, errC, errX := errors.New("a"), errors.New("b"), errors.New("c"), errors.New("x")
+
+	c := rxmerr.NewCollector()
+	c.Append(errA, errB, errC)
+
+	old, err := c.SwapAt(1, errX)
+	if err != nil || old != errB {
+		t.Fatalf("SwapAt(1, x) = %v, %v, want b, nil", old, err)
+	}
+	if got := c.Errors(); !slices.Equal(got, []error{errA, errX, errC}) || c.Len() != 3 {
+		t.Errorf("after replace: Errors() = %v, Len() = %d, want [a x c] and 3", got, c.Len())
+	}
+
+	old, err = c.SwapAt(0, nil)
+	if err != nil || old != errA {
+		t.Fatalf("SwapAt(0, nil) = %v, %v, want a, nil", old, err)
+	}
+	if got := c.Errors(); !slices.Equal(got, []error{errX, errC}) || c.Len() != 2 {
+		t.Errorf("after remove: Errors() = %v, Len() = %d, want [x c] and 2", got, c.Len())
+	}
+}
+
+func TestSwapAtOutOfRange(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(io.EOF)
+
+	for _, i := range []int{-1, 1, 5} {
+		if old, err := c.SwapAt(i, io.ErrClosedPipe); err == nil || old != nil {
+			t.Errorf("SwapAt(%d) = %v, %v, want nil and an error", i, old, err)
+		}
+	}
+	if got := c.Errors(); !slices.Equal(got, []error{io.EOF}) {
+		t.Errorf("Errors() = %v, want the collector unchanged", got)
+	}
+}
+
+func TestSwapAtAggregateEntry(t *testing.T) {
+	joined := errors.Join(errors.New("a"), errors.New("b"))
+	c := rxmerr.NewCollector()
+	c.Append(joined)
+
+	old, err := c.SwapAt(0, nil)
+	if err != nil || old != joined {
+		t.Fatalf("SwapAt(0, nil) = %v, %v, want the joined error", old, err)
+	}
+	if c.Len() != 0 || c.Err() != nil {
+		t.Errorf("after removing the only entry: Len() = %d, Err() = %v, want 0 and nil", c.Len(), c.Err())
+	}
+}
+
+func TestSwapAtCoalesced(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.WithCoalesce(time.Minute, fakeClock(&now)))
+	c.Append(io.EOF, io.EOF, io.EOF, io.ErrClosedPipe)
+
+	if _, err := c.SwapAt(0, errors.New("x")); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len() after replacing a run of 3 = %d, want 2", got)
+	}
+
+	if _, err := c.SwapAt(1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Len(); got != 1 {
+		t.Errorf("Len() after removing one more = %d, want 1", got)
+	}
+}
+
+func TestSwapAtRemovesCoalescedRun(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.WithCoalesce(time.Minute, fakeClock(&now)))
+	c.Append(io.ErrClosedPipe, io.EOF, io.EOF, io.EOF)
+
+	if _, err := c.SwapAt(1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Len(); got != 1 {
+		t.Errorf("Len() after removing a run of 3 = %d, want 1", got)
+	}
+}
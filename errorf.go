@@ -0,0 +1,82 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"fmt"
+	"io"
+)
+
+// Errorf formats according to a format specifier and returns the result as an
+// error, in the same way as fmt.Errorf.
+//
+// With zero or one %w verb, Errorf returns exactly what fmt.Errorf returns.
+// When the format contains multiple %w verbs, Errorf returns an aggregate
+// whose Error() string is the formatted message, while its constituents are
+// the wrapped causes:
+//
+//	err := rxmerr.Errorf("both probes failed: %w and %w", e1, e2)
+//	err.Error()        // "both probes failed: <e1> and <e2>"
+//	rxmerr.Errors(err) // []error{e1, e2}
+//
+// Causes that are themselves multi-errors are flattened into the aggregate,
+// so a cause built with Combine contributes each of its constituents rather
+// than a single nested node. errors.Is and errors.As reach every cause.
+func Errorf(format string, args ...any) error {
+	err := fmt.Errorf(format, args...)
+
+	multi, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return err
+	}
+
+	var causes []error
+	for _, cause := range multi.Unwrap() {
+		causes = append(causes, Errors(cause)...)
+	}
+
+	return &formattedError{msg: err.Error(), errs: causes}
+}
+
+// formattedError is an aggregate carrying its own message. Its constituents
+// are exposed via Unwrap() []error, which makes them visible to Errors and to
+// errors.Is/errors.As.
+type formattedError struct {
+	msg  string
+	errs []error
+}
+
+func (e *formattedError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the constituents of the aggregate.
+func (e *formattedError) Unwrap() []error {
+	return e.errs
+}
+
+// Format implements fmt.Formatter: %+v writes the message followed by a
+// listing of the constituents, each formatted with %+v, %q quotes Error(),
+// and other verbs write Error().
+func (e *formattedError) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		io.WriteString(f, e.msg+"\n")
+		writeVerbose(f, e.errs)
+		return
+	}
+	formatCompact(f, verb, e)
+}
@@ -0,0 +1,90 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+type codeErr struct{ code int }
+
+func (e *codeErr) Error() string { return "code error" }
+
+func TestErrorfNoWrap(t *testing.T) {
+	err := rxmerr.Errorf("plain %d", 42)
+	if got := err.Error(); got != "plain 42" {
+		t.Errorf("Error() = %q, want %q", got, "plain 42")
+	}
+	if got := rxmerr.Errors(err); len(got) != 1 || got[0] != err {
+		t.Errorf("Errors() = %v, want the error itself", got)
+	}
+}
+
+func TestErrorfSingleWrap(t *testing.T) {
+	err := rxmerr.Errorf("read: %w", io.EOF)
+	if got := err.Error(); got != "read: EOF" {
+		t.Errorf("Error() = %q, want %q", got, "read: EOF")
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Error("errors.Is(err, io.EOF) = false, want true")
+	}
+	if got := len(rxmerr.Errors(err)); got != 1 {
+		t.Errorf("len(Errors()) = %d, want 1", got)
+	}
+}
+
+func TestErrorfMultipleWraps(t *testing.T) {
+	custom := &codeErr{code: 7}
+	err := rxmerr.Errorf("both probes failed: %w and %w", io.EOF, custom)
+
+	if got, want := err.Error(), "both probes failed: EOF and code error"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	errs := rxmerr.Errors(err)
+	if len(errs) != 2 || errs[0] != io.EOF || errs[1] != custom {
+		t.Fatalf("Errors() = %v, want [EOF code error]", errs)
+	}
+
+	if !errors.Is(err, io.EOF) {
+		t.Error("errors.Is(err, io.EOF) = false, want true")
+	}
+	var target *codeErr
+	if !errors.As(err, &target) || target.code != 7 {
+		t.Errorf("errors.As(err, *codeErr) = %v, want code 7", target)
+	}
+}
+
+func TestErrorfFlattensAggregateCauses(t *testing.T) {
+	agg := rxmerr.Combine(io.EOF, fs.ErrNotExist)
+	err := rxmerr.Errorf("first: %w, second: %w", agg, fs.ErrPermission)
+
+	errs := rxmerr.Errors(err)
+	if len(errs) != 3 {
+		t.Fatalf("len(Errors()) = %d, want 3: %v", len(errs), errs)
+	}
+	for _, target := range []error{io.EOF, fs.ErrNotExist, fs.ErrPermission} {
+		if !errors.Is(err, target) {
+			t.Errorf("errors.Is(err, %v) = false, want true", target)
+		}
+	}
+}
@@ -0,0 +1,400 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"dirpx.dev/rxmerr"
+)
+
+func ExampleCollector() {
+	c := rxmerr.NewCollector()
+	for _, name := range []string{"a.conf", "b.conf", "c.conf"} {
+		if name != "b.conf" {
+			c.Append(fmt.Errorf("parse %s: %w", name, io.ErrUnexpectedEOF))
+		}
+	}
+
+	if err := c.Err(); err != nil {
+		fmt.Println(c.Len(), "errors")
+		fmt.Println(err)
+	}
+	// Output:
+	// 2 errors
+	// parse a.conf: unexpected EOF; parse c.conf: unexpected EOF
+}
+
+func ExampleCollector_Report() {
+	c := rxmerr.NewCollector()
+	c.Append(errors.New("open config.yaml: permission denied"))
+	c.Append(errors.New("dial tcp 10.0.0.7:443: i/o timeout"))
+
+	fmt.Println(c.Report())
+	// Output:
+	// 2 errors occurred:
+	//   [0] open config.yaml: permission denied
+	//   [1] dial tcp 10.0.0.7:443: i/o timeout
+}
+
+func ExampleCollector_SetContext() {
+	c := rxmerr.NewCollector()
+	c.SetContext("sync routes")
+	fmt.Println(c.Err())
+
+	c.Append(errors.New("route /a: no upstream"))
+	fmt.Println(c.Err())
+	fmt.Println(c.Errors())
+	// Output:
+	// <nil>
+	// sync routes: route /a: no upstream
+	// [route /a: no upstream]
+}
+
+func ExampleCollector_Wrap() {
+	c := rxmerr.NewCollector()
+	db := c.Wrap("db")
+	db.Append(errors.New("connection reset"))
+	db.Wrap("query").Append(errors.New("syntax error"))
+
+	for _, msg := range c.Messages() {
+		fmt.Println(msg)
+	}
+	// Output:
+	// db: connection reset
+	// db: query: syntax error
+}
+
+func ExampleNewCollector() {
+	c := rxmerr.NewCollector(
+		rxmerr.WithIgnore(func(err error) bool { return errors.Is(err, context.Canceled) }),
+		rxmerr.WithTransform(func(err error) error { return fmt.Errorf("worker: %w", err) }),
+	)
+	c.Append(context.Canceled, io.ErrClosedPipe)
+
+	fmt.Println(c.Err())
+	// Output:
+	// worker: io: read/write on closed pipe
+}
+
+func ExampleCombine() {
+	err := rxmerr.Combine(
+		errors.New("first"),
+		nil,
+		errors.New("second"),
+	)
+	fmt.Println(err)
+	fmt.Println(len(rxmerr.Errors(err)))
+	// Output:
+	// first; second
+	// 2
+}
+
+func ExampleAppend() {
+	var err error
+	err = rxmerr.Append(err, nil)
+	fmt.Println(err)
+	err = rxmerr.Append(err, errors.New("a"))
+	err = rxmerr.Append(err, errors.New("b"))
+	fmt.Println(err)
+	// Output:
+	// <nil>
+	// a; b
+}
+
+func ExampleAppendInto() {
+	steps := []func() error{
+		func() error { return nil },
+		func() error { return errors.New("step 2 failed") },
+		func() error { return errors.New("step 3 failed") },
+	}
+
+	var err error
+	for _, step := range steps {
+		rxmerr.AppendInto(&err, step())
+	}
+	fmt.Println(err)
+	// Output:
+	// step 2 failed; step 3 failed
+}
+
+func ExampleErrors() {
+	err := rxmerr.Combine(io.EOF, io.ErrClosedPipe)
+	for i, e := range rxmerr.Errors(err) {
+		fmt.Println(i, e)
+	}
+	// Output:
+	// 0 EOF
+	// 1 io: read/write on closed pipe
+}
+
+func ExampleErrorf() {
+	errA := errors.New("probe a: timeout")
+	errB := errors.New("probe b: refused")
+
+	err := rxmerr.Errorf("both probes failed: %w and %w", errA, errB)
+	fmt.Println(err)
+	fmt.Println(len(rxmerr.Errors(err)), errors.Is(err, errB))
+	// Output:
+	// both probes failed: probe a: timeout and probe b: refused
+	// 2 true
+}
+
+func ExampleCombineVerbose() {
+	err := rxmerr.CombineVerbose(errors.New("a"), errors.New("b"))
+	fmt.Printf("%v\n", err)
+	fmt.Printf("%q\n", err)
+	fmt.Printf("%+v\n", err)
+	// Output:
+	// a; b
+	// "a; b"
+	// 2 errors occurred:
+	//   [0] a
+	//   [1] b
+}
+
+func ExampleFormat() {
+	err := rxmerr.Combine(
+		errors.New("open config.yaml: permission denied"),
+		errors.New("dial tcp 10.0.0.7:443: i/o timeout"),
+	)
+	fmt.Println(rxmerr.Format(err))
+	// Output:
+	// 2 errors occurred:
+	//   [0] open config.yaml: permission denied
+	//   [1] dial tcp 10.0.0.7:443: i/o timeout
+}
+
+func ExampleFormatBullet() {
+	err := rxmerr.Combine(
+		errors.New("open config.yaml: permission denied"),
+		errors.New("line 1\nline 2"),
+	)
+	fmt.Println(rxmerr.FormatBullet(err))
+	// Output:
+	// - open config.yaml: permission denied
+	// - line 1
+	//   line 2
+}
+
+func ExampleMessages() {
+	err := rxmerr.Combine(errors.New("a"), errors.New("b"))
+	fmt.Printf("%q\n", rxmerr.Messages(err))
+	// Output:
+	// ["a" "b"]
+}
+
+func ExampleCombineMap() {
+	err := rxmerr.CombineMap(map[int]error{
+		10: errors.New("disk full"),
+		2:  errors.New("timeout"),
+		7:  nil,
+	})
+	fmt.Println(err)
+	// Output:
+	// 2: timeout; 10: disk full
+}
+
+func ExampleSummarize() {
+	err := rxmerr.Combine(
+		fmt.Errorf("shard 1: %w", context.DeadlineExceeded),
+		fmt.Errorf("shard 2: %w", context.DeadlineExceeded),
+		errors.New("shard 3: bad checksum"),
+	)
+	rules := []rxmerr.SummaryRule{{Label: "timeout", Target: context.DeadlineExceeded}}
+	fmt.Println(rxmerr.Summarize(err, rules))
+	// Output:
+	// 2 timeouts, 1 other
+}
+
+// The constituents of aggregates built concurrently are in completion order.
+// Sorted makes the output deterministic.
+func ExampleSafeCollector() {
+	sc := rxmerr.NewSafeCollector()
+	var wg sync.WaitGroup
+	for _, shard := range []string{"eu", "us", "ap"} {
+		wg.Go(func() {
+			sc.AppendFrom(shard, errors.New("sync failed"))
+		})
+	}
+	wg.Wait()
+
+	for _, msg := range rxmerr.Messages(rxmerr.Sorted(sc.Err())) {
+		fmt.Println(msg)
+	}
+	fmt.Println(sc.SourcesWithErrors())
+	// Output:
+	// ap: sync failed
+	// eu: sync failed
+	// us: sync failed
+	// [ap eu us]
+}
+
+func ExampleCollectUntil() {
+	errFatal := errors.New("schema mismatch")
+	err := rxmerr.CollectUntil(context.Background(),
+		func(err error) bool { return errors.Is(err, errFatal) },
+		func(context.Context) error { return errFatal },
+		func(ctx context.Context) error {
+			<-ctx.Done() // canceled by the fatal error
+			return fmt.Errorf("replica: %w", ctx.Err())
+		},
+	)
+
+	fmt.Printf("%q\n", rxmerr.Messages(rxmerr.Sorted(err)))
+	// Output:
+	// ["replica: context canceled" "schema mismatch"]
+}
+
+func ExampleSorted() {
+	err := rxmerr.Combine(
+		errors.New("shard us: timeout"),
+		errors.New("shard ap: timeout"),
+		errors.New("shard eu: timeout"),
+	)
+	fmt.Println(rxmerr.Sorted(err))
+	// Output:
+	// shard ap: timeout; shard eu: timeout; shard us: timeout
+}
+
+func ExampleDominant() {
+	errTimeout := errors.New("i/o timeout")
+	err := rxmerr.Combine(errTimeout, io.EOF, errTimeout)
+
+	dom, n, majority := rxmerr.Dominant(err)
+	fmt.Println(dom, n, majority)
+	// Output:
+	// i/o timeout 2 true
+}
+
+func ExampleCluster() {
+	err := rxmerr.Combine(
+		errors.New("job 17 failed"),
+		errors.New("permission denied"),
+		errors.New("job 23 failed"),
+	)
+	for _, cl := range rxmerr.Cluster(err, nil) {
+		fmt.Printf("%d× %s\n", cl.Count, cl.Key)
+	}
+	// Output:
+	// 2× job <n> failed
+	// 1× permission denied
+}
+
+func ExampleFormatClustered() {
+	var errs []error
+	for _, host := range []string{"10.0.0.7:443", "10.0.0.8:443", "10.0.0.9:443"} {
+		errs = append(errs, fmt.Errorf("dial tcp %s: i/o timeout", host))
+	}
+	errs = append(errs, errors.New("permission denied"))
+
+	fmt.Println(rxmerr.FormatClustered(rxmerr.Combine(errs...)))
+	// Output:
+	// i/o timeout to 3 hosts (e.g. 10.0.0.7:443); permission denied
+}
+
+func ExampleFanOut() {
+	shards := []string{"eu", "us", "ap"}
+	rep := rxmerr.FanOut{Limit: 2}.Do(context.Background(), shards, func(_ context.Context, shard string) error {
+		if shard == "ap" {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	fmt.Println(rep.Failed())
+	fmt.Println(rep.Err())
+	// Output:
+	// [ap]
+	// ap: connection refused
+}
+
+func ExampleMapResults() {
+	ports, err := rxmerr.MapResults([]string{"80", "http", "443"}, strconv.Atoi)
+
+	fmt.Println(ports)
+	fmt.Println(err)
+	// Output:
+	// [80 443]
+	// strconv.Atoi: parsing "http": invalid syntax
+}
+
+func ExampleRunWithRollback() {
+	err := rxmerr.RunWithRollback([]rxmerr.Step{
+		{
+			Name: "reserve stock",
+			Do:   func() error { return nil },
+			Undo: func() error { return errors.New("warehouse offline") },
+		},
+		{
+			Name: "charge card",
+			Do:   func() error { return errors.New("card declined") },
+		},
+	})
+
+	for _, msg := range rxmerr.Messages(err) {
+		fmt.Println(msg)
+	}
+	fmt.Println(errors.Is(err, rxmerr.ErrRollbackFailed))
+	// Output:
+	// charge card: card declined
+	// rollback of reserve stock failed: warehouse offline
+	// true
+}
+
+func ExampleTranslate() {
+	errNotFound := errors.New("not found")
+	table := []rxmerr.Translation{
+		{Target: io.EOF, Replace: func(error) error { return errNotFound }},
+		{Target: io.ErrShortWrite},
+	}
+
+	err := rxmerr.Translate(rxmerr.Combine(io.EOF, io.ErrShortWrite, io.ErrClosedPipe), table)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, errNotFound))
+	// Output:
+	// not found; io: read/write on closed pipe
+	// true
+}
+
+func ExampleTypedCollector() {
+	tc := rxmerr.NewTypedCollector[*fieldError]()
+	tc.Append(&fieldError{field: "name", problem: "required"})
+	tc.AppendErr(fmt.Errorf("validate: %w", &fieldError{field: "email", problem: "malformed"}))
+	tc.AppendErr(io.EOF) // not a *fieldError: ignored
+
+	for _, fe := range tc.Items() {
+		fmt.Printf("%s: %s\n", fe.field, fe.problem)
+	}
+	fmt.Println(tc.Err())
+	// Output:
+	// name: required
+	// email: malformed
+	// name is required; email is malformed
+}
+
+// fieldError is a domain error type used by ExampleTypedCollector.
+type fieldError struct {
+	field, problem string
+}
+
+func (e *fieldError) Error() string { return e.field + " is " + e.problem }
@@ -0,0 +1,92 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+)
+
+// ErrUsage marks errors caused by invalid command-line usage. DefaultExitCode
+// maps errors matching it to exit code 2:
+//
+//	return fmt.Errorf("%w: unknown flag --%s", rxmerr.ErrUsage, name)
+var ErrUsage = errors.New("rxmerr: usage error")
+
+// DefaultExitCode is the classifier used by ExitCode when none is given.
+//
+// It returns 2, the conventional exit code for usage errors, if err matches
+// ErrUsage or flag.ErrHelp according to errors.Is, and 1 otherwise.
+func DefaultExitCode(err error) int {
+	if errors.Is(err, ErrUsage) || errors.Is(err, flag.ErrHelp) {
+		return 2
+	}
+	return 1
+}
+
+// ExitCode converts err into a process exit code.
+//
+// If err is nil, ExitCode returns 0. Otherwise every constituent of err, as
+// returned by Errors, is classified with classify, and the highest resulting
+// code is returned, so more specific codes take precedence over generic ones
+// when codes are assigned in increasing order of severity or specificity.
+// Because a failed run must not look successful, ExitCode returns 1 if every
+// constituent is classified as 0 or less. If classify is nil, DefaultExitCode
+// is used.
+//
+// A custom classifier can, for example, distinguish partial from total
+// failure:
+//
+//	code := rxmerr.ExitCode(err, func(err error) int {
+//	    if errors.Is(err, errSkippedItem) {
+//	        return 3 // partial failure
+//	    }
+//	    return rxmerr.DefaultExitCode(err)
+//	})
+func ExitCode(err error, classify func(error) int) int {
+	if err == nil {
+		return 0
+	}
+	if classify == nil {
+		classify = DefaultExitCode
+	}
+
+	code := 1
+	for _, e := range Errors(err) {
+		code = max(code, classify(e))
+	}
+	return code
+}
+
+// FprintExit writes the Format rendering of err to w, followed by a newline,
+// and returns ExitCode(err, nil). It is meant to end a command-line program:
+//
+//	func main() {
+//	    os.Exit(rxmerr.FprintExit(os.Stderr, run()))
+//	}
+//
+// If err is nil, nothing is written and FprintExit returns 0. Write errors
+// are ignored.
+func FprintExit(w io.Writer, err error) int {
+	if err == nil {
+		return 0
+	}
+	fmt.Fprintln(w, Format(err))
+	return ExitCode(err, nil)
+}
@@ -0,0 +1,104 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestExitCode(t *testing.T) {
+	errIO := errors.New("disk full")
+	errFlag := fmt.Errorf("%w: unknown flag --v", rxmerr.ErrUsage)
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, 0},
+		{"generic", errIO, 1},
+		{"usage", errFlag, 2},
+		{"help", flag.ErrHelp, 2},
+		{"usage wins", rxmerr.Combine(errIO, errFlag), 2},
+		{"usage wins last", rxmerr.Combine(errFlag, errIO), 2},
+		{"joined", errors.Join(errIO, errFlag), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rxmerr.ExitCode(tt.err, nil); got != tt.want {
+				t.Errorf("ExitCode(%v, nil) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCodeCustom(t *testing.T) {
+	errSkipped := errors.New("item skipped")
+	errFatal := errors.New("connection lost")
+	classify := func(err error) int {
+		if errors.Is(err, errSkipped) {
+			return 3
+		}
+		return rxmerr.DefaultExitCode(err)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, 0},
+		{"total failure", errFatal, 1},
+		{"partial failure", rxmerr.Combine(errSkipped, errSkipped), 3},
+		{"partial wins", rxmerr.Combine(errFatal, errSkipped), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rxmerr.ExitCode(tt.err, classify); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCodeNeverZero(t *testing.T) {
+	zero := func(error) int { return 0 }
+	if got := rxmerr.ExitCode(errors.New("ignored"), zero); got != 1 {
+		t.Errorf("ExitCode() with a classifier returning 0 = %d, want 1", got)
+	}
+}
+
+func TestFprintExit(t *testing.T) {
+	var buf bytes.Buffer
+	if got := rxmerr.FprintExit(&buf, nil); got != 0 || buf.Len() != 0 {
+		t.Errorf("FprintExit(nil) = %d, wrote %q; want 0 and no output", got, buf.String())
+	}
+
+	err := rxmerr.Combine(errors.New("a"), fmt.Errorf("%w: b", rxmerr.ErrUsage))
+	if got := rxmerr.FprintExit(&buf, err); got != 2 {
+		t.Errorf("FprintExit() = %d, want 2", got)
+	}
+	if got, want := buf.String(), rxmerr.Format(err)+"\n"; got != want {
+		t.Errorf("FprintExit() wrote %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,56 @@
+//go:build rxmerr_expvar
+
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+)
+
+// expvarMu serializes PublishExpvar so that the existence check and the
+// registration are atomic with respect to each other.
+var expvarMu sync.Mutex
+
+// PublishExpvar publishes the number of errors collected by c as an
+// expvar.Func named name, which reports c.Len() whenever it is read (for
+// example by the /debug/vars handler).
+//
+// If a variable named name is already published, PublishExpvar returns an
+// error instead of panicking as expvar.Publish would. Published variables
+// cannot be removed; the collector stays referenced for the lifetime of the
+// process.
+//
+// The published function reads the collector from whichever goroutine serves
+// the expvar request. Because Collector is not safe for concurrent use, such
+// reads race with Append unless the caller synchronizes them.
+//
+// This method is only available when building with the rxmerr_expvar build
+// tag, because importing package expvar registers an HTTP handler on
+// http.DefaultServeMux.
+func (c *Collector) PublishExpvar(name string) error {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("rxmerr: expvar %q is already published", name)
+	}
+	expvar.Publish(name, expvar.Func(func() any { return c.Len() }))
+	return nil
+}
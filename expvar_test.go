@@ -0,0 +1,58 @@
+//go:build rxmerr_expvar
+
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"expvar"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestPublishExpvar(t *testing.T) {
+	const name = "rxmerr_test_publish_expvar"
+	c := rxmerr.NewCollector()
+	if err := c.PublishExpvar(name); err != nil {
+		t.Fatalf("PublishExpvar() = %v, want nil", err)
+	}
+
+	v := expvar.Get(name)
+	if v == nil {
+		t.Fatalf("expvar.Get(%q) = nil after PublishExpvar", name)
+	}
+	if got := v.String(); got != "0" {
+		t.Errorf("published value = %s, want 0", got)
+	}
+
+	c.Append(errors.New("a"), errors.New("b"))
+	if got := v.String(); got != "2" {
+		t.Errorf("published value after two appends = %s, want 2", got)
+	}
+}
+
+func TestPublishExpvarDuplicate(t *testing.T) {
+	const name = "rxmerr_test_publish_expvar_duplicate"
+	if err := rxmerr.NewCollector().PublishExpvar(name); err != nil {
+		t.Fatalf("first PublishExpvar() = %v, want nil", err)
+	}
+	if err := rxmerr.NewCollector().PublishExpvar(name); err == nil {
+		t.Error("second PublishExpvar() = nil, want an error")
+	}
+}
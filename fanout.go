@@ -0,0 +1,149 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// FanOut runs a function for a set of named targets concurrently and reports
+// both the failures and the timing of every call. It packages the common
+// scatter-gather pattern, such as reading from several shards:
+//
+//	rep := rxmerr.FanOut{Limit: 4}.Do(ctx, shards, func(ctx context.Context, shard string) error {
+//	    return readShard(ctx, shard)
+//	})
+//	if err := rep.Err(); err != nil {
+//	    name, d := rep.Slowest()
+//	    log.Printf("failed shards %v; slowest healthy shard %s took %s: %v", rep.Failed(), name, d, err)
+//	}
+//
+// The zero value runs all calls at once and measures time with time.Now.
+type FanOut struct {
+	// Limit bounds the number of calls running at the same time. A
+	// non-positive Limit runs all calls at once.
+	Limit int
+
+	// Clock is the time source used to measure durations. If nil, time.Now
+	// is used.
+	Clock func() time.Time
+}
+
+// FanOutReport is the outcome of FanOut.Do.
+type FanOutReport struct {
+	names     []string
+	errs      []error
+	durations []time.Duration
+}
+
+// Do calls fn once for every element of names, concurrently within the limit
+// of f, and waits for all calls to return.
+//
+// Every call receives ctx unchanged; a failing call does not cancel the
+// others. Names SHOULD be unique, as they identify the calls in the report.
+func (f FanOut) Do(ctx context.Context, names []string, fn func(ctx context.Context, name string) error) *FanOutReport {
+	clock := f.Clock
+	if clock == nil {
+		clock = time.Now
+	}
+
+	r := &FanOutReport{
+		names:     names,
+		errs:      make([]error, len(names)),
+		durations: make([]time.Duration, len(names)),
+	}
+
+	var sem chan struct{}
+	if f.Limit > 0 {
+		sem = make(chan struct{}, f.Limit)
+	}
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		if sem != nil {
+			sem <- struct{}{}
+		}
+		wg.Go(func() {
+			if sem != nil {
+				defer func() { <-sem }()
+			}
+			start := clock()
+			r.errs[i] = fn(ctx, name)
+			r.durations[i] = clock().Sub(start)
+		})
+	}
+	wg.Wait()
+
+	return r
+}
+
+// Err returns the aggregate of the errors of the failed calls, each wrapped
+// as fmt.Errorf("%s: %w", name, err), in the order of the names passed to Do.
+// It returns nil if no call failed.
+func (r *FanOutReport) Err() error {
+	var errs []error
+	for i, err := range r.errs {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.names[i], err))
+		}
+	}
+	return Combine(errs...)
+}
+
+// Durations returns the duration of every call, keyed by name.
+func (r *FanOutReport) Durations() map[string]time.Duration {
+	m := make(map[string]time.Duration, len(r.names))
+	for i, name := range r.names {
+		m[name] = r.durations[i]
+	}
+	return m
+}
+
+// Failed returns the names of the failed calls, in the order of the names
+// passed to Do, or nil if no call failed.
+func (r *FanOutReport) Failed() []string {
+	var failed []string
+	for i, err := range r.errs {
+		if err != nil {
+			failed = append(failed, r.names[i])
+		}
+	}
+	return failed
+}
+
+// Slowest returns the name and duration of the slowest successful call.
+//
+// Comparing it with the failures tells timeout pressure, where even the
+// successful calls are slow, from hard failures. When several calls are
+// equally slow, the first in the order of the names passed to Do wins. If no
+// call succeeded, Slowest returns ("", 0).
+func (r *FanOutReport) Slowest() (string, time.Duration) {
+	var (
+		name    string
+		slowest time.Duration
+		found   bool
+	)
+	for i, err := range r.errs {
+		if err == nil && (!found || r.durations[i] > slowest) {
+			name, slowest, found = r.names[i], r.durations[i], true
+		}
+	}
+	return name, slowest
+}
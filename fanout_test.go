@@ -0,0 +1,130 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestFanOut(t *testing.T) {
+	errTimeout := errors.New("i/o timeout")
+	latency := map[string]time.Duration{
+		"shard-0": 20 * time.Millisecond,
+		"shard-1": 300 * time.Millisecond,
+		"shard-2": 80 * time.Millisecond,
+		"shard-3": 500 * time.Millisecond,
+		"shard-4": 80 * time.Millisecond,
+	}
+	failing := map[string]error{"shard-1": errTimeout, "shard-3": errors.New("connection refused")}
+
+	// With a limit of 1 the calls run one after another, so each call can
+	// advance the fake clock by its own latency.
+	var (
+		mu  sync.Mutex
+		now = time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	names := slices.Sorted(maps.Keys(latency))
+	rep := rxmerr.FanOut{Limit: 1, Clock: clock}.Do(context.Background(), names, func(_ context.Context, name string) error {
+		mu.Lock()
+		now = now.Add(latency[name])
+		mu.Unlock()
+		return failing[name]
+	})
+
+	if got, want := rep.Err().Error(), "shard-1: i/o timeout; shard-3: connection refused"; got != want {
+		t.Errorf("Err() = %q, want %q", got, want)
+	}
+	if !errors.Is(rep.Err(), errTimeout) {
+		t.Errorf("errors.Is(Err(), errTimeout) = false")
+	}
+	if got, want := rep.Failed(), []string{"shard-1", "shard-3"}; !slices.Equal(got, want) {
+		t.Errorf("Failed() = %q, want %q", got, want)
+	}
+	if got := rep.Durations(); !maps.Equal(got, latency) {
+		t.Errorf("Durations() = %v, want %v", got, latency)
+	}
+	if name, d := rep.Slowest(); name != "shard-2" || d != 80*time.Millisecond {
+		t.Errorf("Slowest() = (%q, %v), want (\"shard-2\", 80ms)", name, d)
+	}
+}
+
+func TestFanOutNoFailures(t *testing.T) {
+	rep := rxmerr.FanOut{}.Do(context.Background(), []string{"a", "b"}, func(context.Context, string) error {
+		return nil
+	})
+	if err := rep.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+	if got := rep.Failed(); got != nil {
+		t.Errorf("Failed() = %q, want nil", got)
+	}
+	if got := len(rep.Durations()); got != 2 {
+		t.Errorf("len(Durations()) = %d, want 2", got)
+	}
+}
+
+func TestFanOutAllFailed(t *testing.T) {
+	rep := rxmerr.FanOut{}.Do(context.Background(), []string{"a", "b"}, func(context.Context, string) error {
+		return errors.New("down")
+	})
+	if name, d := rep.Slowest(); name != "" || d != 0 {
+		t.Errorf("Slowest() = (%q, %v), want (\"\", 0)", name, d)
+	}
+	if got, want := rep.Failed(), []string{"a", "b"}; !slices.Equal(got, want) {
+		t.Errorf("Failed() = %q, want %q", got, want)
+	}
+}
+
+func TestFanOutLimit(t *testing.T) {
+	const limit = 2
+	var running, peak atomic.Int32
+	names := []string{"a", "b", "c", "d", "e", "f"}
+
+	rep := rxmerr.FanOut{Limit: limit}.Do(context.Background(), names, func(context.Context, string) error {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return nil
+	})
+
+	if got := peak.Load(); got > limit {
+		t.Errorf("peak concurrency = %d, want at most %d", got, limit)
+	}
+	if got := len(rep.Durations()); got != len(names) {
+		t.Errorf("len(Durations()) = %d, want %d", got, len(names))
+	}
+}
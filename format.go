@@ -0,0 +1,124 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"slices"
+	"strings"
+)
+
+// Format returns the canonical human-readable rendering of err: a multi-line
+// listing with one numbered constituent per line, in the same layout as
+// Collector.Report and the %+v output of CombineVerbose:
+//
+//	2 errors occurred:
+//	  [0] open config.yaml: permission denied
+//	  [1] dial tcp 10.0.0.7:443: i/o timeout
+//
+// Constituents are obtained via Errors. The aggregates returned by
+// MergeStages are listed grouped by stage. The result has no trailing
+// newline. If err is nil, Format returns an empty string.
+func Format(err error) string {
+	errs := Errors(err)
+	if len(errs) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	if se, ok := err.(*stagesError); ok {
+		se.writeGrouped(&b)
+	} else {
+		writeVerbose(&b, errs)
+	}
+	return b.String()
+}
+
+// FormatBullet renders the constituents of err as a bulleted list with one
+// constituent per line:
+//
+//	fmt.Println(rxmerr.FormatBullet(err))
+//	// - open config.yaml: permission denied
+//	// - dial tcp 10.0.0.7:443: i/o timeout
+//
+// Constituents are obtained via Errors. Continuation lines of multi-line
+// messages are indented to stay attached to their bullet. The result has no
+// trailing newline. If err is nil, FormatBullet returns an empty string.
+func FormatBullet(err error) string {
+	var b strings.Builder
+	for i, e := range Errors(err) {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString("- ")
+		b.WriteString(strings.ReplaceAll(e.Error(), "\n", "\n  "))
+	}
+	return b.String()
+}
+
+// Messages returns the Error() strings of the constituents of err, in the
+// order returned by Errors:
+//
+//	rxmerr.Messages(rxmerr.Combine(errA, errB)) // []string{"a", "b"}
+//
+// If err is nil, Messages returns nil.
+func Messages(err error) []string {
+	errs := Errors(err)
+	if len(errs) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	return msgs
+}
+
+// ErrorsMap returns the messages of the constituents of err keyed by their
+// position in Errors(err).
+//
+// This shape is convenient for JSON APIs whose clients address errors by
+// index, for example {"0": "first failure", "1": "second failure"}. If err is
+// nil, ErrorsMap returns an empty, non-nil map.
+func ErrorsMap(err error) map[int]string {
+	errs := Errors(err)
+	m := make(map[int]string, len(errs))
+	for i, e := range errs {
+		m[i] = e.Error()
+	}
+	return m
+}
+
+// Sorted returns an aggregate of the constituents of err sorted by their
+// Error() strings.
+//
+// Aggregates produced by concurrent helpers such as CollectUntil or
+// SafeCollector list constituents in completion order, which varies between
+// runs. Sorting makes their rendering deterministic, which is what logs that
+// are diffed across runs, golden files, and Example output blocks need:
+//
+//	fmt.Println(rxmerr.Sorted(err))
+//
+// The sort is stable, so constituents with identical messages keep their
+// relative order. Constituents are not modified and remain reachable via
+// errors.Is and errors.As. If err is nil, Sorted returns nil; if err has a
+// single constituent, it is returned as-is.
+func Sorted(err error) error {
+	errs := Errors(err)
+	slices.SortStableFunc(errs, func(a, b error) int {
+		return strings.Compare(a.Error(), b.Error())
+	})
+	return Combine(errs...)
+}
@@ -0,0 +1,128 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestErrorsMap(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want map[int]string
+	}{
+		{name: "nil", err: nil, want: map[int]string{}},
+		{name: "single", err: errors.New("a"), want: map[int]string{0: "a"}},
+		{
+			name: "multi",
+			err:  rxmerr.Combine(errors.New("a"), nil, errors.New("b"), errors.New("c")),
+			want: map[int]string{0: "a", 1: "b", 2: "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rxmerr.ErrorsMap(tt.err)
+			if got == nil {
+				t.Fatal("ErrorsMap() = nil, want a non-nil map")
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("ErrorsMap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessages(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"nil", nil, nil},
+		{"single", io.EOF, []string{"EOF"}},
+		{"wrapped", fmt.Errorf("read: %w", io.EOF), []string{"read: EOF"}},
+		{"multi", rxmerr.Combine(errors.New("a"), io.EOF, errors.New("b")), []string{"a", "EOF", "b"}},
+		{"multi-line", rxmerr.Combine(errors.New("a\nb"), io.EOF), []string{"a\nb", "EOF"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rxmerr.Messages(tt.err); !slices.Equal(got, tt.want) {
+				t.Errorf("Messages() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectorMessages(t *testing.T) {
+	c := rxmerr.NewCollector()
+	if got := c.Messages(); got != nil {
+		t.Errorf("Messages() on empty collector = %q, want nil", got)
+	}
+
+	c.Append(errors.New("a"), nil, io.EOF)
+	if got, want := c.Messages(), []string{"a", "EOF"}; !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+
+	c.SetContext("sync")
+	if got, want := c.Messages(), []string{"a", "EOF"}; !slices.Equal(got, want) {
+		t.Errorf("Messages() with context = %q, want %q", got, want)
+	}
+}
+
+func TestSorted(t *testing.T) {
+	errB := errors.New("b")
+	errA1, errA2 := errors.New("a"), errors.New("a")
+
+	tests := []struct {
+		name string
+		err  error
+		want []error
+	}{
+		{name: "nil", err: nil},
+		{name: "single", err: errB, want: []error{errB}},
+		{name: "sorted by message", err: rxmerr.Combine(errB, io.EOF, errA1), want: []error{io.EOF, errA1, errB}},
+		{name: "stable", err: rxmerr.Combine(errA2, errB, errA1), want: []error{errA2, errA1, errB}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rxmerr.Sorted(tt.err)
+			if gotErrs := rxmerr.Errors(got); !slices.Equal(gotErrs, tt.want) {
+				t.Errorf("Errors(Sorted()) = %v, want %v", gotErrs, tt.want)
+			}
+		})
+	}
+
+	if err := rxmerr.Sorted(errB); err != errB {
+		t.Errorf("Sorted(single) = %#v, want the error itself", err)
+	}
+	original := rxmerr.Combine(errB, errA1)
+	rxmerr.Sorted(original)
+	if got := rxmerr.Errors(original); !slices.Equal(got, []error{errB, errA1}) {
+		t.Errorf("Errors(original) after Sorted = %v, want it unchanged", got)
+	}
+}
@@ -0,0 +1,128 @@
+//go:build rxmerr_grpc
+
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCError is a collected gRPC status.
+//
+// GRPCError implements the GRPCStatus method recognized by status.FromError,
+// so a GRPCError (or an aggregate containing one) can be converted back to a
+// *status.Status by gRPC middleware.
+//
+// This type is only available when building with the rxmerr_grpc build tag.
+type GRPCError struct {
+	// Code is the gRPC status code.
+	Code codes.Code
+
+	// Message is the status message.
+	Message string
+}
+
+// Error returns a message in the same format as errors produced by package
+// status, for example "rpc error: code = Unavailable desc = connection reset".
+func (e *GRPCError) Error() string {
+	return fmt.Sprintf("rpc error: code = %s desc = %s", e.Code, e.Message)
+}
+
+// GRPCStatus returns e as a *status.Status.
+func (e *GRPCError) GRPCStatus() *status.Status {
+	return status.New(e.Code, e.Message)
+}
+
+// AppendGRPCStatus appends s to c as a *GRPCError.
+//
+// If s is nil or its code is codes.OK, AppendGRPCStatus does nothing.
+//
+// This function is only available when building with the rxmerr_grpc build
+// tag.
+func AppendGRPCStatus(c *Collector, s *status.Status) {
+	if s == nil || s.Code() == codes.OK {
+		return
+	}
+	c.Append(&GRPCError{Code: s.Code(), Message: s.Message()})
+}
+
+// GRPCErrors returns the gRPC statuses of all collected errors that carry
+// one, in collection order.
+//
+// Both errors appended with AppendGRPCStatus and errors returned by gRPC
+// clients are recognized, including when they were wrapped before being
+// appended. If no gRPC errors were collected, GRPCErrors returns nil.
+//
+// This method is only available when building with the rxmerr_grpc build tag.
+func (c *Collector) GRPCErrors() []*status.Status {
+	var out []*status.Status
+	for _, err := range c.Errors() {
+		if s, ok := status.FromError(err); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// FirstGRPCCode returns the code of the first collected gRPC error, or
+// codes.OK if none was collected. See GRPCErrors.
+//
+// This method is only available when building with the rxmerr_grpc build tag.
+func (c *Collector) FirstGRPCCode() codes.Code {
+	for _, err := range c.Errors() {
+		if s, ok := status.FromError(err); ok {
+			return s.Code()
+		}
+	}
+	return codes.OK
+}
+
+// SetErrorMetadata stores the encoding of err (see EncodeHeader) in md under
+// HeaderKey. If err is nil, the key is removed.
+//
+// This function is only available when building with the rxmerr_grpc build
+// tag.
+func SetErrorMetadata(md metadata.MD, err error) error {
+	s, encErr := EncodeHeader(err)
+	if encErr != nil {
+		return encErr
+	}
+	if s == "" {
+		md.Delete(HeaderKey)
+		return nil
+	}
+	md.Set(HeaderKey, s)
+	return nil
+}
+
+// ErrorFromMetadata decodes the aggregate stored in md by SetErrorMetadata.
+// It returns nil if the key is absent. See DecodeHeader.
+//
+// This function is only available when building with the rxmerr_grpc build
+// tag.
+func ErrorFromMetadata(md metadata.MD) error {
+	vals := md.Get(HeaderKey)
+	if len(vals) == 0 {
+		return nil
+	}
+	return DecodeHeader(vals[0])
+}
@@ -0,0 +1,122 @@
+//go:build rxmerr_grpc
+
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestGRPCError(t *testing.T) {
+	err := &rxmerr.GRPCError{Code: codes.Unavailable, Message: "connection reset"}
+	if got, want := err.Error(), status.Error(codes.Unavailable, "connection reset").Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	s, ok := status.FromError(rxmerr.Combine(io.EOF, fmt.Errorf("call: %w", err)))
+	if !ok || s.Code() != codes.Unavailable {
+		t.Errorf("status.FromError(aggregate) = (%v, %v), want code Unavailable", s, ok)
+	}
+}
+
+func TestAppendGRPCStatus(t *testing.T) {
+	c := rxmerr.NewCollector()
+	rxmerr.AppendGRPCStatus(c, nil)
+	rxmerr.AppendGRPCStatus(c, status.New(codes.OK, "fine"))
+	if c.HasError() {
+		t.Fatalf("Err() after nil and OK statuses = %v, want nil", c.Err())
+	}
+
+	rxmerr.AppendGRPCStatus(c, status.New(codes.NotFound, "no such user"))
+	var ge *rxmerr.GRPCError
+	if !errors.As(c.Err(), &ge) || ge.Code != codes.NotFound || ge.Message != "no such user" {
+		t.Errorf("Err() = %#v, want a *GRPCError with code NotFound", c.Err())
+	}
+}
+
+func TestGRPCErrors(t *testing.T) {
+	c := rxmerr.NewCollector()
+	if got := c.GRPCErrors(); got != nil {
+		t.Errorf("GRPCErrors() on empty collector = %v, want nil", got)
+	}
+	if got := c.FirstGRPCCode(); got != codes.OK {
+		t.Errorf("FirstGRPCCode() on empty collector = %v, want OK", got)
+	}
+
+	c.Append(io.EOF)
+	if got := c.GRPCErrors(); got != nil {
+		t.Errorf("GRPCErrors() without gRPC errors = %v, want nil", got)
+	}
+
+	rxmerr.AppendGRPCStatus(c, status.New(codes.DeadlineExceeded, "slow"))
+	c.Append(fmt.Errorf("get user: %w", status.Error(codes.PermissionDenied, "denied")))
+
+	var got []codes.Code
+	for _, s := range c.GRPCErrors() {
+		got = append(got, s.Code())
+	}
+	if want := []codes.Code{codes.DeadlineExceeded, codes.PermissionDenied}; !slices.Equal(got, want) {
+		t.Errorf("GRPCErrors() codes = %v, want %v", got, want)
+	}
+	if got := c.FirstGRPCCode(); got != codes.DeadlineExceeded {
+		t.Errorf("FirstGRPCCode() = %v, want DeadlineExceeded", got)
+	}
+}
+
+func TestErrorMetadata(t *testing.T) {
+	md := metadata.MD{}
+	err := rxmerr.Combine(rxmerr.WithCode(errors.New("db unavailable"), "DB_UNAVAILABLE"), io.EOF)
+	if setErr := rxmerr.SetErrorMetadata(md, err); setErr != nil {
+		t.Fatalf("SetErrorMetadata() error = %v", setErr)
+	}
+
+	decoded := rxmerr.ErrorFromMetadata(md)
+	if got, want := rxmerr.Messages(decoded), []string{"db unavailable", "EOF"}; !slices.Equal(got, want) {
+		t.Errorf("Messages(ErrorFromMetadata()) = %q, want %q", got, want)
+	}
+	if code, ok := rxmerr.CodeOf(decoded); !ok || code != "DB_UNAVAILABLE" {
+		t.Errorf("CodeOf() = (%q, %v), want (\"DB_UNAVAILABLE\", true)", code, ok)
+	}
+
+	if setErr := rxmerr.SetErrorMetadata(md, nil); setErr != nil {
+		t.Fatalf("SetErrorMetadata(nil) error = %v", setErr)
+	}
+	if vals := md.Get(rxmerr.HeaderKey); len(vals) != 0 {
+		t.Errorf("SetErrorMetadata(nil) kept the key: %q", vals)
+	}
+	if got := rxmerr.ErrorFromMetadata(md); got != nil {
+		t.Errorf("ErrorFromMetadata() without key = %v, want nil", got)
+	}
+}
+
+func TestErrorFromMetadataMalformed(t *testing.T) {
+	md := metadata.Pairs(rxmerr.HeaderKey, "not base64!")
+	if err := rxmerr.ErrorFromMetadata(md); !errors.Is(err, rxmerr.ErrMalformedHeader) {
+		t.Errorf("ErrorFromMetadata() = %v, want ErrMalformedHeader", err)
+	}
+}
@@ -0,0 +1,178 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// HeaderKey is the name of the HTTP header (and gRPC metadata key) used by
+// SetErrorHeader and ErrorFromHeader to carry an encoded aggregate.
+const HeaderKey = "X-Rxmerr-Errors"
+
+// MaxHeaderBytes is the maximum length of a value produced by EncodeHeader.
+const MaxHeaderBytes = 4096
+
+// maxHeaderMessageRunes bounds the length of a single encoded message, so
+// that at least one constituent always fits into MaxHeaderBytes.
+const maxHeaderMessageRunes = 256
+
+// headerVersion is the version of the encoding produced by EncodeHeader.
+const headerVersion = 1
+
+// ErrMalformedHeader is returned (wrapped) by DecodeHeader when its input is
+// not a valid encoded aggregate.
+var ErrMalformedHeader = errors.New("rxmerr: malformed error header")
+
+// headerPayload is the JSON document carried by an encoded header.
+type headerPayload struct {
+	Version int           `json:"v"`
+	Errors  []headerEntry `json:"e,omitempty"`
+	Omitted int           `json:"o,omitempty"`
+}
+
+// headerEntry is a single encoded constituent.
+type headerEntry struct {
+	Message string `json:"m"`
+	Code    string `json:"c,omitempty"`
+}
+
+// EncodeHeader encodes the constituents of err into a compact string suitable
+// for an HTTP header or gRPC metadata value, so that an aggregate can be
+// forwarded across a process boundary and reconstructed with DecodeHeader.
+//
+// For every constituent, only its message and its code (see WithCode) are
+// encoded. Type identity and wrapped causes do NOT survive the round trip: on
+// the receiving side, errors.Is and errors.As match only against the plain
+// errors created by DecodeHeader, and CodeOf is the way to classify them.
+//
+// The result is base64 (URL alphabet, unpadded) and at most MaxHeaderBytes
+// long. Messages longer than 256 runes are cut with an ellipsis, and if the
+// aggregate still does not fit, trailing constituents are omitted; their
+// number is recorded, and DecodeHeader reports it as an additional error.
+//
+// If err is nil, EncodeHeader returns an empty string.
+func EncodeHeader(err error) (string, error) {
+	errs := Errors(err)
+	if len(errs) == 0 {
+		return "", nil
+	}
+
+	entries := make([]headerEntry, len(errs))
+	for i, e := range errs {
+		entries[i].Message, _ = truncateRunes(e.Error(), maxHeaderMessageRunes)
+		entries[i].Code, _ = CodeOf(e)
+	}
+
+	// Find the largest prefix of entries that fits into MaxHeaderBytes.
+	var encErr error
+	keep := sort.Search(len(entries)+1, func(n int) bool {
+		s, err := encodeHeaderPayload(entries, n)
+		if err != nil {
+			encErr = err
+			return true
+		}
+		return len(s) > MaxHeaderBytes
+	}) - 1
+	if encErr != nil {
+		return "", encErr
+	}
+	return encodeHeaderPayload(entries, max(keep, 0))
+}
+
+// encodeHeaderPayload encodes the first n entries, recording the rest as
+// omitted.
+func encodeHeaderPayload(entries []headerEntry, n int) (string, error) {
+	b, err := json.Marshal(headerPayload{
+		Version: headerVersion,
+		Errors:  entries[:n],
+		Omitted: len(entries) - n,
+	})
+	if err != nil {
+		return "", fmt.Errorf("rxmerr: encode error header: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeHeader reconstructs an aggregate from a string produced by
+// EncodeHeader.
+//
+// Every encoded constituent becomes a plain error with the original message,
+// annotated with its original code as by WithCode if it had one. Decoded
+// codes are not validated in strict mode (see SetStrictCodes), as they come
+// from the peer rather than from this program. If constituents were omitted
+// during encoding, a final error such as "rxmerr: 12 more errors omitted" is
+// added. See EncodeHeader for what is
+// lost in transit.
+//
+// If s is empty, DecodeHeader returns nil. If s is not a valid encoding,
+// DecodeHeader returns an error matching ErrMalformedHeader with errors.Is.
+func DecodeHeader(s string) error {
+	if s == "" {
+		return nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedHeader, err)
+	}
+	var p headerPayload
+	if err := json.Unmarshal(b, &p); err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedHeader, err)
+	}
+	if p.Version != headerVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrMalformedHeader, p.Version)
+	}
+	if p.Omitted < 0 {
+		return fmt.Errorf("%w: negative omitted count", ErrMalformedHeader)
+	}
+
+	errs := make([]error, 0, len(p.Errors)+1)
+	for _, e := range p.Errors {
+		errs = append(errs, withCode(errors.New(e.Message), e.Code))
+	}
+	if p.Omitted > 0 {
+		errs = append(errs, fmt.Errorf("rxmerr: %d more errors omitted", p.Omitted))
+	}
+	return Combine(errs...)
+}
+
+// SetErrorHeader stores the encoding of err in h under HeaderKey. If err is
+// nil, the header is removed.
+func SetErrorHeader(h http.Header, err error) error {
+	s, encErr := EncodeHeader(err)
+	if encErr != nil {
+		return encErr
+	}
+	if s == "" {
+		h.Del(HeaderKey)
+		return nil
+	}
+	h.Set(HeaderKey, s)
+	return nil
+}
+
+// ErrorFromHeader decodes the aggregate stored in h by SetErrorHeader. It
+// returns nil if the header is absent. See DecodeHeader.
+func ErrorFromHeader(h http.Header) error {
+	return DecodeHeader(h.Get(HeaderKey))
+}
@@ -0,0 +1,155 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestHeaderRoundTrip(t *testing.T) {
+	err := rxmerr.Combine(
+		rxmerr.WithCode(errors.New("db unavailable"), "DB_UNAVAILABLE"),
+		fmt.Errorf("wrapped: %w", errors.New("plain")),
+	)
+
+	s, encErr := rxmerr.EncodeHeader(err)
+	if encErr != nil {
+		t.Fatalf("EncodeHeader() error = %v", encErr)
+	}
+	decoded := rxmerr.DecodeHeader(s)
+
+	want := []string{"db unavailable", "wrapped: plain"}
+	if got := rxmerr.Messages(decoded); !slices.Equal(got, want) {
+		t.Errorf("Messages(DecodeHeader()) = %q, want %q", got, want)
+	}
+	errs := rxmerr.Errors(decoded)
+	if code, ok := rxmerr.CodeOf(errs[0]); !ok || code != "DB_UNAVAILABLE" {
+		t.Errorf("CodeOf(first) = %q, %v, want DB_UNAVAILABLE", code, ok)
+	}
+	if _, ok := rxmerr.CodeOf(errs[1]); ok {
+		t.Error("CodeOf(second) reported a code, want none")
+	}
+}
+
+func TestDecodeHeaderStrictCodes(t *testing.T) {
+	h := http.Header{}
+	if err := rxmerr.SetErrorHeader(h, rxmerr.WithCode(errors.New("remote failure"), "TEST_REMOTE_CODE")); err != nil {
+		t.Fatalf("SetErrorHeader() error = %v", err)
+	}
+	strictCodes(t)
+
+	decoded := rxmerr.ErrorFromHeader(h)
+	if got, want := rxmerr.Messages(decoded), []string{"remote failure"}; !slices.Equal(got, want) {
+		t.Errorf("Messages(ErrorFromHeader()) = %q, want %q", got, want)
+	}
+	if code, ok := rxmerr.CodeOf(decoded); !ok || code != "TEST_REMOTE_CODE" {
+		t.Errorf("CodeOf() = (%q, %v), want (\"TEST_REMOTE_CODE\", true)", code, ok)
+	}
+	if errors.Is(decoded, rxmerr.ErrUnregisteredCode) {
+		t.Errorf("errors.Is(%v, ErrUnregisteredCode) = true, want remote codes not validated", decoded)
+	}
+}
+
+func TestHeaderNil(t *testing.T) {
+	s, err := rxmerr.EncodeHeader(nil)
+	if s != "" || err != nil {
+		t.Errorf("EncodeHeader(nil) = %q, %v, want empty", s, err)
+	}
+	if err := rxmerr.DecodeHeader(""); err != nil {
+		t.Errorf(`DecodeHeader("") = %v, want nil`, err)
+	}
+}
+
+func TestHeaderTruncation(t *testing.T) {
+	errs := make([]error, 200)
+	for i := range errs {
+		errs[i] = fmt.Errorf("upstream %03d failed: %s", i, strings.Repeat("x", 300))
+	}
+
+	s, err := rxmerr.EncodeHeader(rxmerr.Combine(errs...))
+	if err != nil {
+		t.Fatalf("EncodeHeader() error = %v", err)
+	}
+	if len(s) > rxmerr.MaxHeaderBytes {
+		t.Errorf("len(EncodeHeader()) = %d, want at most %d", len(s), rxmerr.MaxHeaderBytes)
+	}
+
+	decoded := rxmerr.Errors(rxmerr.DecodeHeader(s))
+	if len(decoded) < 2 || len(decoded) >= len(errs) {
+		t.Fatalf("decoded %d errors, want some but not all of %d", len(decoded), len(errs))
+	}
+	first := decoded[0].Error()
+	if !strings.HasPrefix(first, "upstream 000 failed: ") || !strings.HasSuffix(first, "…") {
+		t.Errorf("first decoded message = %q, want it cut with an ellipsis", first)
+	}
+	kept := len(decoded) - 1
+	want := fmt.Sprintf("rxmerr: %d more errors omitted", len(errs)-kept)
+	if got := decoded[kept].Error(); got != want {
+		t.Errorf("last decoded message = %q, want %q", got, want)
+	}
+}
+
+func TestDecodeHeaderMalformed(t *testing.T) {
+	encode := func(json string) string { return base64.RawURLEncoding.EncodeToString([]byte(json)) }
+
+	tests := []struct {
+		name string
+		s    string
+	}{
+		{name: "not base64", s: "!!!"},
+		{name: "not json", s: encode("{")},
+		{name: "wrong shape", s: encode(`{"v":1,"e":"x"}`)},
+		{name: "unknown version", s: encode(`{"v":99}`)},
+		{name: "negative omitted", s: encode(`{"v":1,"o":-1}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rxmerr.DecodeHeader(tt.s); !errors.Is(err, rxmerr.ErrMalformedHeader) {
+				t.Errorf("DecodeHeader() = %v, want ErrMalformedHeader", err)
+			}
+		})
+	}
+}
+
+func TestErrorHeader(t *testing.T) {
+	h := http.Header{}
+	if err := rxmerr.SetErrorHeader(h, errors.New("a")); err != nil {
+		t.Fatalf("SetErrorHeader() error = %v", err)
+	}
+	if got := rxmerr.ErrorFromHeader(h); got == nil || got.Error() != "a" {
+		t.Errorf("ErrorFromHeader() = %v, want a", got)
+	}
+
+	if err := rxmerr.SetErrorHeader(h, nil); err != nil {
+		t.Fatalf("SetErrorHeader(nil) error = %v", err)
+	}
+	if _, ok := h[rxmerr.HeaderKey]; ok {
+		t.Error("SetErrorHeader(nil) kept the header")
+	}
+	if got := rxmerr.ErrorFromHeader(h); got != nil {
+		t.Errorf("ErrorFromHeader() without header = %v, want nil", got)
+	}
+}
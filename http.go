@@ -0,0 +1,171 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// httpBodySnippetLimit is the maximum number of response body bytes captured
+// by AppendHTTPError.
+const httpBodySnippetLimit = 512
+
+// HTTPError describes a failed HTTP exchange.
+type HTTPError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Body is a snippet of the response body, with surrounding whitespace
+	// trimmed. It MAY be empty.
+	Body string
+
+	// Err is the underlying cause, if any. It is returned by Unwrap.
+	Err error
+}
+
+// Error returns the status code and text, followed by the body snippet and
+// the underlying error if present, for example
+// "503 Service Unavailable: upstream overloaded".
+func (e *HTTPError) Error() string {
+	msg := e.status()
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// status returns the status code and text, followed by the body snippet if
+// present.
+func (e *HTTPError) status() string {
+	msg := strconv.Itoa(e.StatusCode)
+	if text := http.StatusText(e.StatusCode); text != "" {
+		msg += " " + text
+	}
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+// Unwrap returns the underlying cause, if any.
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+// Format implements fmt.Formatter: %+v formats the underlying cause, if any,
+// with %+v, %q quotes Error(), and other verbs write Error().
+func (e *HTTPError) Format(f fmt.State, verb rune) {
+	if e.Err == nil {
+		formatCompact(f, verb, e)
+		return
+	}
+	formatWrapper(f, verb, e, e.status()+": ", e.Err)
+}
+
+// AppendHTTPError appends an *HTTPError describing resp to c if resp has a
+// non-2xx status code.
+//
+// Up to 512 bytes of the response body are read into HTTPError.Body. The body
+// is neither drained nor closed; closing it remains the caller's
+// responsibility. A read error while capturing the snippet is ignored and
+// leaves the snippet truncated. If resp is nil or its status code is in the
+// 2xx range, AppendHTTPError does nothing.
+//
+//	resp, err := client.Do(req)
+//	if err != nil {
+//	    c.Append(err)
+//	    return
+//	}
+//	defer resp.Body.Close()
+//	rxmerr.AppendHTTPError(c, resp)
+func AppendHTTPError(c *Collector, resp *http.Response) {
+	if resp == nil || resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return
+	}
+
+	herr := &HTTPError{StatusCode: resp.StatusCode}
+	if resp.Body != nil {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, httpBodySnippetLimit))
+		herr.Body = strings.TrimSpace(string(snippet))
+	}
+	c.Append(herr)
+}
+
+// AppendHTTP appends err to the collector as an *HTTPError carrying status.
+//
+// This is useful when calling several upstreams and aggregating their
+// failures together with the status each one answered with:
+//
+//	c.AppendHTTP(resp.StatusCode, fmt.Errorf("upstream %s: %w", name, err))
+//
+// The original err remains reachable via errors.Is and errors.As. If err is
+// nil, AppendHTTP does nothing, in the same way as Append.
+func (c *Collector) AppendHTTP(status int, err error) {
+	if err != nil {
+		c.Append(&HTTPError{StatusCode: status, Err: err})
+	}
+}
+
+// WorstStatus returns the highest status code among the collected HTTP
+// errors, or 0 if none were collected. See HTTPErrors.
+//
+// In a router this is typically the status to answer the client with when
+// several upstreams failed, for example 503 rather than 500.
+func (c *Collector) WorstStatus() int {
+	worst := 0
+	for _, herr := range c.HTTPErrors() {
+		worst = max(worst, herr.StatusCode)
+	}
+	return worst
+}
+
+// HTTPErrors returns the HTTP errors among the collected errors, in
+// collection order.
+//
+// Collected errors are matched with errors.As, so an *HTTPError that was
+// wrapped before being appended is still found. If no HTTP errors were
+// collected, HTTPErrors returns nil.
+func (c *Collector) HTTPErrors() []HTTPError {
+	var out []HTTPError
+	for _, err := range c.Errors() {
+		if herr, ok := AsHTTPError(err); ok {
+			out = append(out, *herr)
+		}
+	}
+	return out
+}
+
+// AsHTTPError finds the first *HTTPError in err's tree, as errors.As does.
+//
+// When err is an aggregate, its constituents are searched in order. This
+// allows status-code-based handling of collected errors:
+//
+//	if herr, ok := rxmerr.AsHTTPError(err); ok && herr.StatusCode == http.StatusNotFound {
+//	    ...
+//	}
+func AsHTTPError(err error) (*HTTPError, bool) {
+	var herr *HTTPError
+	if errors.As(err, &herr) {
+		return herr, true
+	}
+	return nil, false
+}
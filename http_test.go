@@ -0,0 +1,67 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestAppendHTTP(t *testing.T) {
+	errA := errors.New("upstream a")
+	errB := errors.New("upstream b")
+
+	c := rxmerr.NewCollector()
+	c.AppendHTTP(500, errA)
+	c.AppendHTTP(503, errB)
+	c.AppendHTTP(502, nil)
+
+	if got := c.Len(); got != 2 {
+		t.Fatalf("Len() = %d, want 2", got)
+	}
+	if got := c.WorstStatus(); got != 503 {
+		t.Errorf("WorstStatus() = %d, want 503", got)
+	}
+
+	err := c.Err()
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("Err() = %v, originals not reachable via errors.Is", err)
+	}
+	want := "500 Internal Server Error: upstream a; 503 Service Unavailable: upstream b"
+	if got := err.Error(); got != want {
+		t.Errorf("Err() = %q, want %q", got, want)
+	}
+
+	herrs := c.HTTPErrors()
+	if len(herrs) != 2 || herrs[0].StatusCode != 500 || herrs[1].StatusCode != 503 {
+		t.Errorf("HTTPErrors() = %v, want statuses [500 503]", herrs)
+	}
+}
+
+func TestWorstStatusWithoutHTTPErrors(t *testing.T) {
+	c := rxmerr.NewCollector()
+	if got := c.WorstStatus(); got != 0 {
+		t.Errorf("WorstStatus() on empty collector = %d, want 0", got)
+	}
+
+	c.Append(errors.New("plain"))
+	if got := c.WorstStatus(); got != 0 {
+		t.Errorf("WorstStatus() without HTTP errors = %d, want 0", got)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import "reflect"
+
+// CountedError is a stored entry of a Collector together with the number of
+// consecutive times it was appended. See Collector.Counted.
+type CountedError struct {
+	// Err is the stored error value.
+	Err error
+
+	// N is the number of consecutive appends of Err represented by the entry.
+	// It is always at least 1.
+	N int
+}
+
+// Counted returns the stored entries of the collector in collection order,
+// each with the number of consecutive appends it represents.
+//
+// A Collector interns runs of identical error values: when the same value is
+// appended several times in a row, as happens when a sentinel error is
+// reported for every item of a large batch, it is stored once with a count
+// instead of once per append. Counted exposes this compact form directly,
+// without materializing the repeats:
+//
+//	for _, e := range c.Counted() {
+//	    log.Printf("%d× %v", e.N, e.Err)
+//	}
+//
+// Errors, Err and the other accessors present the logical view, in which every
+// append is listed separately. Values are identical if they are equal according
+// to ==; errors whose dynamic values are not comparable are never interned.
+// Interning is disabled while WithCoalesce is in effect, which already merges
+// repeated errors.
+//
+// If no errors were collected, Counted returns nil.
+func (c *Collector) Counted() []CountedError {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	counted := make([]CountedError, len(c.errs))
+	for i, err := range c.errs {
+		counted[i] = CountedError{Err: err, N: 1 + c.repeatsAt(i)}
+	}
+	return counted
+}
+
+// internInto records err as a repeat of the last stored entry if both are the
+// same value, reporting whether it did.
+func (c *Collector) internInto(err error) bool {
+	n := len(c.errs)
+	if n == 0 || c.coalesce != nil || !sameValue(c.errs[n-1], err) {
+		return false
+	}
+	if c.reps == nil {
+		c.reps = make([]int, n, cap(c.errs))
+	}
+	c.reps[n-1]++
+	c.err = nil
+	return true
+}
+
+// repeatsAt returns the number of repeats interned into the i-th stored entry.
+func (c *Collector) repeatsAt(i int) int {
+	if c.reps == nil {
+		return 0
+	}
+	return c.reps[i]
+}
+
+// expanded returns the stored errors with interned repeats materialized. The
+// result aliases c.errs if nothing was interned.
+func (c *Collector) expanded() []error {
+	if c.reps == nil {
+		return c.errs
+	}
+	total := len(c.errs)
+	for _, r := range c.reps {
+		total += r
+	}
+	errs := make([]error, 0, total)
+	for i, err := range c.errs {
+		for range 1 + c.reps[i] {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// sameValue reports whether a and b are the same error value according to ==,
+// without panicking on dynamic values that are not comparable.
+func sameValue(a, b error) bool {
+	ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+	if ta != tb {
+		return false
+	}
+	if ta.Kind() != reflect.Pointer && !reflect.ValueOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
@@ -0,0 +1,105 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+// sliceErr is an error type whose values are not comparable with ==.
+type sliceErr []string
+
+func (e sliceErr) Error() string { return "slice error" }
+
+func TestCounted(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(io.EOF, io.EOF, io.EOF, io.ErrClosedPipe, io.EOF)
+
+	counted := c.Counted()
+	want := []rxmerr.CountedError{{io.EOF, 3}, {io.ErrClosedPipe, 1}, {io.EOF, 1}}
+	if len(counted) != len(want) {
+		t.Fatalf("Counted() = %v, want %v", counted, want)
+	}
+	for i := range want {
+		if counted[i] != want[i] {
+			t.Errorf("Counted()[%d] = %v, want %v", i, counted[i], want[i])
+		}
+	}
+	if got := c.Len(); got != 5 {
+		t.Errorf("Len() = %d, want 5", got)
+	}
+	if got := len(c.Errors()); got != 5 {
+		t.Errorf("len(Errors()) = %d, want 5", got)
+	}
+}
+
+func TestCountedNotComparable(t *testing.T) {
+	err := sliceErr{"a"}
+	c := rxmerr.NewCollector()
+	c.Append(err, err) // must not panic
+
+	if got := len(c.Counted()); got != 2 {
+		t.Errorf("len(Counted()) = %d, want 2", got)
+	}
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+}
+
+func TestCountedEqualMessagesNotInterned(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(errors.New("x"), errors.New("x"))
+
+	if got := len(c.Counted()); got != 2 {
+		t.Errorf("len(Counted()) = %d, want 2: distinct values with equal messages", got)
+	}
+}
+
+const sentinelAppends = 100_000
+
+// BenchmarkAppendSentinelInterned appends one sentinel 100k times in a row,
+// which interning stores as a single entry.
+func BenchmarkAppendSentinelInterned(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		c := rxmerr.NewCollector()
+		for range sentinelAppends {
+			c.Append(io.EOF)
+		}
+	}
+}
+
+// BenchmarkAppendSentinelNotInterned appends two alternating sentinels 100k
+// times in total, which interning cannot merge: one interface slot is stored
+// per append, as without interning.
+func BenchmarkAppendSentinelNotInterned(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		c := rxmerr.NewCollector()
+		for i := range sentinelAppends {
+			if i%2 == 0 {
+				c.Append(io.EOF)
+			} else {
+				c.Append(io.ErrUnexpectedEOF)
+			}
+		}
+	}
+}
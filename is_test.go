@@ -0,0 +1,291 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"dirpx.dev/rxmerr"
+)
+
+var errNotFound = errors.New("not found")
+
+// notFoundError is the leaf of the matrix: errors.Is finds errNotFound
+// through it and errors.As finds it by type.
+type notFoundError struct{ key string }
+
+func (e *notFoundError) Error() string { return e.key + ": not found" }
+
+func (e *notFoundError) Unwrap() error { return errNotFound }
+
+// isAsShape builds an error tree around leaf with leaf at position pos among
+// its siblings, for the shapes that have positions.
+type isAsShape struct {
+	name      string
+	positions int
+	build     func(leaf error, pos int) error
+}
+
+// siblingsWith returns n sibling errors with leaf at position pos.
+func siblingsWith(leaf error, pos, n int) []error {
+	errs := make([]error, n)
+	for i := range errs {
+		errs[i] = fmt.Errorf("sibling %d", i)
+	}
+	errs[pos] = leaf
+	return errs
+}
+
+var isAsShapes = []isAsShape{
+	{"plain", 1, func(leaf error, _ int) error { return leaf }},
+	{"fmt-wrapped", 1, func(leaf error, _ int) error { return fmt.Errorf("lookup: %w", leaf) }},
+	{"multierr", 3, func(leaf error, pos int) error {
+		return rxmerr.Combine(siblingsWith(leaf, pos, 3)...)
+	}},
+	{"errors.Join", 3, func(leaf error, pos int) error {
+		return errors.Join(siblingsWith(leaf, pos, 3)...)
+	}},
+	{"fmt multi-%w", 3, func(leaf error, pos int) error {
+		errs := siblingsWith(leaf, pos, 3)
+		return fmt.Errorf("%w, %w, %w", errs[0], errs[1], errs[2])
+	}},
+	{"fmt-wrapped multierr", 3, func(leaf error, pos int) error {
+		return fmt.Errorf("batch: %w", rxmerr.Combine(siblingsWith(leaf, pos, 3)...))
+	}},
+	{"fmt-wrapped errors.Join", 3, func(leaf error, pos int) error {
+		return fmt.Errorf("batch: %w", errors.Join(siblingsWith(leaf, pos, 3)...))
+	}},
+}
+
+// isAsDepths nests an error tree one and two further levels deep.
+var isAsDepths = []struct {
+	name string
+	nest func(err error) error
+}{
+	{"depth1", func(err error) error { return err }},
+	{"depth2", func(err error) error {
+		return rxmerr.Combine(errors.New("outer"), fmt.Errorf("stage: %w", err))
+	}},
+	{"depth3", func(err error) error {
+		inner := rxmerr.Combine(fmt.Errorf("stage: %w", err), errors.New("inner"))
+		return errors.Join(errors.New("outer"), fmt.Errorf("run: %w", inner))
+	}},
+}
+
+func collected(opts ...rxmerr.Option) func(func(c *rxmerr.Collector)) error {
+	return func(fill func(c *rxmerr.Collector)) error {
+		c := rxmerr.NewCollector(opts...)
+		fill(c)
+		return c.Err()
+	}
+}
+
+// isAsWrappers are the ways an error reaches an aggregate or wrapper of this
+// package.
+var isAsWrappers = []struct {
+	name string
+	wrap func(err error) error
+}{
+	{"Combine", func(err error) error { return rxmerr.Combine(errors.New("x"), err) }},
+	{"Append", func(err error) error { return rxmerr.Append(errors.New("x"), err) }},
+	{"AppendInto", func(err error) error {
+		dst := errors.New("x")
+		rxmerr.AppendInto(&dst, err)
+		return dst
+	}},
+	{"JoinCombine", func(err error) error { return rxmerr.JoinCombine(errors.New("x"), err) }},
+	{"CombineVerbose", func(err error) error { return rxmerr.CombineVerbose(errors.New("x"), err) }},
+	{"CombineDebug", func(err error) error { return rxmerr.CombineDebug(errors.New("x"), err) }},
+	{"CombineNetError", func(err error) error { return rxmerr.CombineNetError(errors.New("x"), err) }},
+	{"CombineSafeDepth", func(err error) error { return rxmerr.CombineSafeDepth(32, errors.New("x"), err) }},
+	{"CombineWithContext", func(err error) error {
+		return rxmerr.CombineWithContext(map[string]any{"req": 1}, errors.New("x"), err)
+	}},
+	{"CombineMap", func(err error) error {
+		return rxmerr.CombineMap(map[int]error{1: errors.New("x"), 2: err})
+	}},
+	{"NewMultiError", func(err error) error { return rxmerr.NewMultiError(errors.New("x"), err) }},
+	{"Errorf single", func(err error) error { return rxmerr.Errorf("op: %w", err) }},
+	{"Errorf multi", func(err error) error { return rxmerr.Errorf("%w and %w", errors.New("x"), err) }},
+	{"Sorted", func(err error) error { return rxmerr.Sorted(rxmerr.Combine(errors.New("x"), err)) }},
+	{"Translate", func(err error) error { return rxmerr.Translate(rxmerr.Combine(errors.New("x"), err), nil) }},
+	{"TruncateMessages", func(err error) error {
+		return rxmerr.TruncateMessages(rxmerr.Combine(errors.New("x"), err), 4)
+	}},
+	{"TruncateMessage", func(err error) error { return rxmerr.TruncateMessage(err, 4) }},
+	{"WithCode", func(err error) error { return rxmerr.WithCode(err, "E_LOOKUP") }},
+	{"WithRetryAfter", func(err error) error { return rxmerr.WithRetryAfter(err, time.Second) }},
+	{"WrapSummarized", func(err error) error {
+		return rxmerr.WrapSummarized(rxmerr.Combine(errors.New("x"), err), "sync failed", nil)
+	}},
+	{"FromPanic", func(err error) error { return rxmerr.FromPanic(err) }},
+	{"RunWithRollback", func(err error) error {
+		return rxmerr.RunWithRollback([]rxmerr.Step{
+			{Name: "a", Undo: func() error { return errors.New("x") }},
+			{Name: "b", Do: func() error { return err }},
+		})
+	}},
+	{"Collector", func(err error) error {
+		return collected()(func(c *rxmerr.Collector) { c.Append(errors.New("x"), err) })
+	}},
+	{"Collector.SetContext", func(err error) error {
+		return collected()(func(c *rxmerr.Collector) {
+			c.SetContext("sync")
+			c.Append(err)
+		})
+	}},
+	{"Collector.Prefix", func(err error) error {
+		return collected()(func(c *rxmerr.Collector) {
+			c.Prefix("db: ")
+			c.Append(err)
+		})
+	}},
+	{"Collector.Wrap", func(err error) error {
+		return collected()(func(c *rxmerr.Collector) { c.Wrap("db").Append(err) })
+	}},
+	{"Collector.ToError", func(err error) error {
+		c := rxmerr.NewCollector()
+		c.Append(errors.New("x"), err)
+		return c.ToError()
+	}},
+	{"Collector.View", func(err error) error {
+		c := rxmerr.NewCollector()
+		c.Append(err)
+		return c.View().Err()
+	}},
+	{"WithJoinBackend", func(err error) error {
+		return collected(rxmerr.WithJoinBackend())(func(c *rxmerr.Collector) { c.Append(errors.New("x"), err) })
+	}},
+	{"WithCoalesce", func(err error) error {
+		now := time.Now()
+		return collected(rxmerr.WithCoalesce(time.Minute, func() time.Time { return now }))(
+			func(c *rxmerr.Collector) { c.Append(err, err) })
+	}},
+	{"CollapseConsecutive", func(err error) error {
+		return collected(rxmerr.CollapseConsecutive())(func(c *rxmerr.Collector) { c.Append(err, err) })
+	}},
+	{"WithMaxMessageBytes", func(err error) error {
+		return collected(rxmerr.WithMaxMessageBytes(4))(func(c *rxmerr.Collector) { c.Append(err) })
+	}},
+	{"WithTransform", func(err error) error {
+		wrap := func(err error) error { return fmt.Errorf("t: %w", err) }
+		return collected(rxmerr.WithTransform(wrap))(func(c *rxmerr.Collector) { c.Append(err) })
+	}},
+	{"WithContext", func(err error) error {
+		return collected(rxmerr.WithContext(context.Background()))(func(c *rxmerr.Collector) { c.Append(err) })
+	}},
+	{"AppendCoded", func(err error) error {
+		return collected()(func(c *rxmerr.Collector) { c.AppendCoded(404, err) })
+	}},
+	{"AppendHTTP", func(err error) error {
+		return collected()(func(c *rxmerr.Collector) { c.AppendHTTP(404, err) })
+	}},
+	{"AppendWithSource", func(err error) error {
+		return collected()(func(c *rxmerr.Collector) { c.AppendWithSource("db", err) })
+	}},
+	{"AppendWithTTL", func(err error) error {
+		return collected()(func(c *rxmerr.Collector) { c.AppendWithTTL(err, time.Hour) })
+	}},
+	{"AppendSentinel", func(err error) error {
+		return collected()(func(c *rxmerr.Collector) { c.AppendSentinel(err) })
+	}},
+	{"AppendWeighted", func(err error) error {
+		return collected()(func(c *rxmerr.Collector) { c.AppendWeighted(3, err) })
+	}},
+	{"MergeStages", func(err error) error {
+		parse := rxmerr.NewCollector()
+		parse.Append(errors.New("x"))
+		load := rxmerr.NewCollector()
+		load.Append(err)
+		return rxmerr.MergeStages([]rxmerr.Stage{{Name: "parse", C: parse}, {Name: "load", C: load}})
+	}},
+	{"Pipeline", func(err error) error {
+		p := rxmerr.NewPipeline()
+		p.Stage("load").Append(err)
+		return p.Err()
+	}},
+	{"PosCollector", func(err error) error {
+		pc := rxmerr.NewPosCollector()
+		pc.AppendAt(rxmerr.Pos{File: "a.conf", Line: 3}, err)
+		return pc.Err()
+	}},
+	{"SafeCollector", func(err error) error {
+		sc := rxmerr.NewSafeCollector()
+		sc.Append(errors.New("x"))
+		sc.Append(err)
+		return sc.Err()
+	}},
+	{"SafeCollector.AppendFrom", func(err error) error {
+		sc := rxmerr.NewSafeCollector()
+		sc.AppendFrom("worker-1", err)
+		return sc.Err()
+	}},
+}
+
+// TestIsAsMatrix checks that errors.Is and errors.As reach the leaf of every
+// error tree shape, at every position and nesting depth, through every
+// aggregate and wrapper of this package.
+func TestIsAsMatrix(t *testing.T) {
+	for _, w := range isAsWrappers {
+		for _, shape := range isAsShapes {
+			for pos := range shape.positions {
+				for _, depth := range isAsDepths {
+					name := fmt.Sprintf("%s/%s/pos%d/%s", w.name, shape.name, pos, depth.name)
+					t.Run(name, func(t *testing.T) {
+						leaf := &notFoundError{key: "user/42"}
+						err := w.wrap(depth.nest(shape.build(leaf, pos)))
+
+						if !errors.Is(err, errNotFound) {
+							t.Errorf("errors.Is(%v, errNotFound) = false, want true", err)
+						}
+						if !errors.Is(err, leaf) {
+							t.Errorf("errors.Is(%v, leaf) = false, want true", err)
+						}
+						var target *notFoundError
+						if !errors.As(err, &target) || target != leaf {
+							t.Errorf("errors.As(%v, *notFoundError) = %v, want %v", err, target, leaf)
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+// TestIsAsMatrixNested wraps every wrapper of the matrix in every other one.
+func TestIsAsMatrixNested(t *testing.T) {
+	for _, outer := range isAsWrappers {
+		for _, inner := range isAsWrappers {
+			t.Run(outer.name+"/"+inner.name, func(t *testing.T) {
+				leaf := &notFoundError{key: "user/42"}
+				err := outer.wrap(fmt.Errorf("stage: %w", inner.wrap(leaf)))
+
+				if !errors.Is(err, errNotFound) {
+					t.Errorf("errors.Is(%v, errNotFound) = false, want true", err)
+				}
+				var target *notFoundError
+				if !errors.As(err, &target) || target != leaf {
+					t.Errorf("errors.As(%v, *notFoundError) = %v, want %v", err, target, leaf)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,50 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import "iter"
+
+// Iter returns an iterator over the collected errors, in the order returned
+// by Errors:
+//
+//	for err := range c.Iter() {
+//	    log.Print(err)
+//	}
+//
+// The errors are captured when iteration starts, so appending to c while
+// ranging over the iterator does not affect the current iteration.
+func (c *Collector) Iter() iter.Seq[error] {
+	return func(yield func(error) bool) {
+		for _, err := range c.Errors() {
+			if !yield(err) {
+				return
+			}
+		}
+	}
+}
+
+// IndexedIter is like Iter but yields the index of every error together with
+// the error, in the same way as ranging over the slice returned by Errors.
+func (c *Collector) IndexedIter() iter.Seq2[int, error] {
+	return func(yield func(int, error) bool) {
+		for i, err := range c.Errors() {
+			if !yield(i, err) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestIter(t *testing.T) {
+	errA := errors.New("a")
+	c := rxmerr.NewCollector()
+	c.Append(errA, io.EOF, io.ErrClosedPipe)
+
+	if got := slices.Collect(c.Iter()); !slices.Equal(got, c.Errors()) {
+		t.Errorf("Iter() = %v, want %v", got, c.Errors())
+	}
+
+	var got []error
+	for err := range c.Iter() {
+		got = append(got, err)
+		if err == io.EOF {
+			break
+		}
+	}
+	if !slices.Equal(got, []error{errA, io.EOF}) {
+		t.Errorf("Iter() with break = %v, want [a EOF]", got)
+	}
+
+	n := 0
+	for range c.Iter() {
+		n++
+		c.Append(errors.New("appended while ranging"))
+	}
+	if n != 3 {
+		t.Errorf("Iter() yielded %d errors while appending, want 3", n)
+	}
+}
+
+func TestIterEmpty(t *testing.T) {
+	c := rxmerr.NewCollector()
+	for err := range c.Iter() {
+		t.Errorf("Iter() on empty collector yielded %v", err)
+	}
+	for i, err := range c.IndexedIter() {
+		t.Errorf("IndexedIter() on empty collector yielded %d, %v", i, err)
+	}
+}
+
+func TestIndexedIter(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(errors.New("a"), io.EOF, io.ErrClosedPipe)
+
+	var indices []int
+	for i, err := range c.IndexedIter() {
+		if err != c.Errors()[i] {
+			t.Errorf("IndexedIter() yielded %d, %v, want %v", i, err, c.Errors()[i])
+		}
+		indices = append(indices, i)
+		if i == 1 {
+			break
+		}
+	}
+	if !slices.Equal(indices, []int{0, 1}) {
+		t.Errorf("IndexedIter() with break yielded indices %v, want [0 1]", indices)
+	}
+}
+
+func TestIndexedIterRateNote(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.MaxRate(1, fakeClock(&now)))
+	c.Append(errors.New("a"), errors.New("b"), errors.New("c"))
+
+	var indices []int
+	var msgs []string
+	for i, err := range c.IndexedIter() {
+		indices = append(indices, i)
+		msgs = append(msgs, err.Error())
+	}
+	if !slices.Equal(indices, []int{0, 1}) {
+		t.Errorf("IndexedIter() indices = %v, want [0 1]", indices)
+	}
+	if want := []string{"a", "rxmerr: dropped 2 errors due to rate limit"}; !slices.Equal(msgs, want) {
+		t.Errorf("IndexedIter() messages = %q, want %q", msgs, want)
+	}
+}
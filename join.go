@@ -0,0 +1,73 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"reflect"
+)
+
+// joinType is the dynamic type of aggregates created by errors.Join.
+var joinType = reflect.TypeOf(errors.Join(errors.New("")))
+
+// JoinCombine merges errs like Combine, but builds the aggregate with
+// errors.Join instead of go.uber.org/multierr.
+//
+// Nil arguments are ignored. If all errs are nil, JoinCombine returns nil; if
+// exactly one is non-nil, it is returned as-is. Otherwise the result is an
+// errors.Join aggregate: its Unwrap() []error method has the standard library
+// shape and its Error() string separates constituents with newlines rather
+// than "; ". Aggregates created by errors.Join (including earlier JoinCombine
+// results) are flattened into the result, mirroring how Combine flattens
+// multierr aggregates.
+//
+// Errors and the other helpers of this package accept aggregates of either
+// backend and treat them identically.
+func JoinCombine(errs ...error) error {
+	var flat []error
+	for _, err := range errs {
+		switch {
+		case err == nil:
+		case reflect.TypeOf(err) == joinType:
+			flat = append(flat, err.(interface{ Unwrap() []error }).Unwrap()...)
+		default:
+			flat = append(flat, err)
+		}
+	}
+
+	switch len(flat) {
+	case 0:
+		return nil
+	case 1:
+		return flat[0]
+	default:
+		return errors.Join(flat...)
+	}
+}
+
+// WithJoinBackend returns an option that makes the collector build its
+// aggregate with JoinCombine instead of multierr.Combine.
+//
+// The aggregate returned by Err then has the errors.Join shape, which suits
+// code bases that standardize on the standard library. Everything else,
+// including Len, Errors, and the options pipeline, behaves identically on
+// either backend.
+func WithJoinBackend() Option {
+	return func(c *Collector) {
+		c.joinBackend = true
+	}
+}
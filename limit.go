@@ -0,0 +1,63 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+// LimitView is a view of a Collector that accepts a bounded number of
+// appends. See Collector.Limit.
+type LimitView struct {
+	base      *Collector
+	remaining int
+}
+
+// Limit returns a view of c that forwards at most n more non-nil errors to c.
+//
+// Once the view's budget is exhausted, further errors appended through the
+// view are silently dropped; they are neither stored nor counted by c. The
+// collector itself is not limited: appends made directly to c, or through
+// other views, are unaffected. Each view has its own budget, while all views
+// share the collector's storage:
+//
+//	perItem := c.Limit(3) // report at most three problems for this item
+//	for _, field := range item.Fields {
+//	    perItem.Append(validate(field))
+//	}
+//
+// A non-positive n yields a view that drops every error.
+func (c *Collector) Limit(n int) *LimitView {
+	return &LimitView{base: c, remaining: max(n, 0)}
+}
+
+// Append forwards a non-nil err to the underlying collector while the view's
+// budget is positive, consuming one unit of budget. Nil errors do not consume
+// budget.
+func (v *LimitView) Append(err error) {
+	if err == nil || v.remaining == 0 {
+		return
+	}
+	v.remaining--
+	v.base.Append(err)
+}
+
+// Remaining returns the number of errors the view will still forward.
+func (v *LimitView) Remaining() int {
+	return v.remaining
+}
+
+// Base returns the underlying collector.
+func (v *LimitView) Base() *Collector {
+	return v.base
+}
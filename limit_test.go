@@ -0,0 +1,92 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestLimit(t *testing.T) {
+	c := rxmerr.NewCollector()
+	v := c.Limit(2)
+	if v.Base() != c {
+		t.Errorf("Base() = %p, want %p", v.Base(), c)
+	}
+
+	steps := []struct {
+		err           error
+		wantRemaining int
+		wantLen       int
+	}{
+		{err: nil, wantRemaining: 2, wantLen: 0},
+		{err: errors.New("a"), wantRemaining: 1, wantLen: 1},
+		{err: nil, wantRemaining: 1, wantLen: 1},
+		{err: errors.New("b"), wantRemaining: 0, wantLen: 2},
+		{err: errors.New("c"), wantRemaining: 0, wantLen: 2},
+	}
+	for i, s := range steps {
+		v.Append(s.err)
+		if got := v.Remaining(); got != s.wantRemaining {
+			t.Errorf("step %d: Remaining() = %d, want %d", i, got, s.wantRemaining)
+		}
+		if got := c.Len(); got != s.wantLen {
+			t.Errorf("step %d: Len() = %d, want %d", i, got, s.wantLen)
+		}
+	}
+
+	c.Append(io.EOF)
+	if got, want := c.Messages(), []string{"a", "b", "EOF"}; !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want direct appends unaffected by the view: %q", got, want)
+	}
+}
+
+func TestLimitIndependentViews(t *testing.T) {
+	c := rxmerr.NewCollector()
+	first, second := c.Limit(1), c.Limit(2)
+
+	for i := range 3 {
+		first.Append(fmt.Errorf("first %d", i))
+		second.Append(fmt.Errorf("second %d", i))
+	}
+
+	if first.Remaining() != 0 || second.Remaining() != 0 {
+		t.Errorf("Remaining() = %d and %d, want 0 and 0", first.Remaining(), second.Remaining())
+	}
+	if got, want := c.Messages(), []string{"first 0", "second 0", "second 1"}; !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+}
+
+func TestLimitNonPositive(t *testing.T) {
+	c := rxmerr.NewCollector()
+	for _, n := range []int{0, -1} {
+		v := c.Limit(n)
+		v.Append(io.EOF)
+		if got := v.Remaining(); got != 0 {
+			t.Errorf("Limit(%d).Remaining() = %d, want 0", n, got)
+		}
+	}
+	if c.HasError() {
+		t.Errorf("Err() = %v, want nil", c.Err())
+	}
+}
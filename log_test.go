@@ -0,0 +1,121 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"maps"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+// traceIDKey is a context key logged as "trace_id".
+type traceIDKey struct{}
+
+func (traceIDKey) String() string { return "trace_id" }
+
+// logRecord is a log record captured by recordingHandler.
+type logRecord struct {
+	level slog.Level
+	msg   string
+	attrs map[string]any
+}
+
+// recordingHandler is a slog.Handler that captures records.
+type recordingHandler struct {
+	records *[]logRecord
+}
+
+func (recordingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h recordingHandler) Handle(_ context.Context, r slog.Record) error {
+	rec := logRecord{level: r.Level, msg: r.Message, attrs: map[string]any{}}
+	r.Attrs(func(a slog.Attr) bool {
+		rec.attrs[a.Key] = a.Value.Resolve().Any()
+		return true
+	})
+	*h.records = append(*h.records, rec)
+	return nil
+}
+
+func (h recordingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+
+func (h recordingHandler) WithGroup(string) slog.Handler { return h }
+
+// newRecordingLogger returns a logger whose records are appended to records.
+func newRecordingLogger(records *[]logRecord) *slog.Logger {
+	return slog.New(recordingHandler{records: records})
+}
+
+func TestLog(t *testing.T) {
+	ctx := context.WithValue(context.Background(), traceIDKey{}, "4bf92f35")
+	c := rxmerr.NewCollector(rxmerr.WithContext(ctx), rxmerr.WithContextKeys(traceIDKey{}))
+	c.Append(errors.New("a"), io.EOF)
+
+	var records []logRecord
+	c.Log(newRecordingLogger(&records).Error)
+
+	if len(records) != 2 {
+		t.Fatalf("Log() produced %d records, want 2", len(records))
+	}
+	for i, want := range []string{"a", "EOF"} {
+		r := records[i]
+		if r.level != slog.LevelError || r.msg != want {
+			t.Errorf("record %d = %v %q, want ERROR %q", i, r.level, r.msg, want)
+		}
+		wantAttrs := map[string]any{"index": int64(i), "total": int64(2), "trace_id": "4bf92f35"}
+		if !maps.Equal(r.attrs, wantAttrs) {
+			t.Errorf("record %d attrs = %v, want %v", i, r.attrs, wantAttrs)
+		}
+	}
+}
+
+func TestLogAll(t *testing.T) {
+	ctx := context.WithValue(context.Background(), traceIDKey{}, "4bf92f35")
+	c := rxmerr.NewCollector(rxmerr.WithContext(ctx), rxmerr.WithContextKeys(traceIDKey{}))
+	c.Append(errors.New("a"), io.EOF)
+
+	var records []logRecord
+	c.LogAll(newRecordingLogger(&records).Warn)
+
+	if len(records) != 1 {
+		t.Fatalf("LogAll() produced %d records, want 1", len(records))
+	}
+	r := records[0]
+	if r.level != slog.LevelWarn || r.msg != c.Report() {
+		t.Errorf("record = %v %q, want WARN %q", r.level, r.msg, c.Report())
+	}
+	wantAttrs := map[string]any{"count": int64(2), "trace_id": "4bf92f35"}
+	if !maps.Equal(r.attrs, wantAttrs) {
+		t.Errorf("record attrs = %v, want %v", r.attrs, wantAttrs)
+	}
+}
+
+func TestLogEmpty(t *testing.T) {
+	c := rxmerr.NewCollector()
+	var records []logRecord
+	logger := newRecordingLogger(&records)
+	c.Log(logger.Error)
+	c.LogAll(logger.Error)
+	if len(records) != 0 {
+		t.Errorf("Log() and LogAll() on empty collector produced %d records, want 0", len(records))
+	}
+}
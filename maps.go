@@ -0,0 +1,102 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// CombineMap merges the non-nil errors stored in m into a single error, in
+// ascending key order.
+//
+// Each error is wrapped with its key as fmt.Errorf("%v: %w", key, err), so the
+// original error remains reachable via errors.Is and errors.As. Because keys
+// are ordered with their natural ordering rather than as strings, int-keyed
+// maps (for example shard IDs) sort numerically:
+//
+//	err := rxmerr.CombineMap(map[int]error{10: errA, 2: errB})
+//	// "2: <errB>; 10: <errA>"
+//
+// Map iteration order is random in Go; sorting is what makes the result
+// deterministic. If m contains no non-nil errors, CombineMap returns nil.
+func CombineMap[K cmp.Ordered](m map[K]error) error {
+	return CombineMapFunc(m, func(k K) string { return fmt.Sprint(k) }, cmp.Less[K])
+}
+
+// CombineMapFunc is like CombineMap but works with keys that have no natural
+// ordering.
+//
+// Keys are sorted with less and rendered with keyString when wrapping. less
+// MUST define a strict weak ordering over the keys of m; keys that compare as
+// equal are emitted in an unspecified order. If m contains no non-nil errors,
+// CombineMapFunc returns nil.
+func CombineMapFunc[K comparable](m map[K]error, keyString func(K) string, less func(a, b K) bool) error {
+	keys := slices.SortedFunc(maps.Keys(m), func(a, b K) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	var errs []error
+	for _, k := range keys {
+		if err := m[k]; err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", keyString(k), err))
+		}
+	}
+	return Combine(errs...)
+}
+
+// CollectMap calls fn for every entry of m and combines the non-nil results.
+//
+// The errors returned by fn are combined as-is; fn has access to the key and
+// can include it in its error if needed. Because map iteration order is
+// random, the order of the constituents of the result varies between calls;
+// use CollectMapSorted when it matters. If fn returns nil for every entry, or
+// m is empty, CollectMap returns nil.
+func CollectMap[K comparable, V any](m map[K]V, fn func(K, V) error) error {
+	var errs []error
+	for k, v := range m {
+		if err := fn(k, v); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return Combine(errs...)
+}
+
+// CollectMapSorted is like CollectMap but visits the entries of m in
+// ascending key order, so the result is deterministic:
+//
+//	err := rxmerr.CollectMapSorted(cfg.Routes, func(name string, r Route) error {
+//	    return r.Validate()
+//	})
+func CollectMapSorted[K cmp.Ordered, V any](m map[K]V, fn func(K, V) error) error {
+	var errs []error
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		if err := fn(k, m[k]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return Combine(errs...)
+}
@@ -0,0 +1,149 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestCombineMap(t *testing.T) {
+	tests := []struct {
+		name string
+		m    map[int]error
+		want string
+	}{
+		{name: "nil map", m: nil},
+		{name: "only nil errors", m: map[int]error{1: nil, 2: nil}},
+		{
+			name: "numeric order",
+			m:    map[int]error{10: errors.New("ten"), 2: errors.New("two"), 1: errors.New("one")},
+			want: "1: one; 2: two; 10: ten",
+		},
+		{
+			name: "nil errors skipped",
+			m:    map[int]error{3: nil, 20: errors.New("twenty"), 4: errors.New("four")},
+			want: "4: four; 20: twenty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rxmerr.CombineMap(tt.m)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("CombineMap() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("CombineMap() = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCombineMapWraps(t *testing.T) {
+	err := rxmerr.CombineMap(map[string]error{"b": io.EOF, "a": io.ErrClosedPipe})
+	if got, want := err.Error(), "a: io: read/write on closed pipe; b: EOF"; got != want {
+		t.Errorf("CombineMap() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, io.EOF) || !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("CombineMap() = %v, constituents not reachable via errors.Is", err)
+	}
+}
+
+type shardKey struct {
+	region string
+	id     int
+}
+
+func TestCombineMapFunc(t *testing.T) {
+	m := map[shardKey]error{
+		{"us", 2}:  errors.New("c"),
+		{"eu", 10}: errors.New("b"),
+		{"eu", 9}:  errors.New("a"),
+		{"us", 1}:  nil,
+	}
+	keyString := func(k shardKey) string { return fmt.Sprintf("%s/%d", k.region, k.id) }
+	less := func(a, b shardKey) bool {
+		if a.region != b.region {
+			return a.region < b.region
+		}
+		return a.id < b.id
+	}
+
+	err := rxmerr.CombineMapFunc(m, keyString, less)
+	if got, want := err.Error(), "eu/9: a; eu/10: b; us/2: c"; got != want {
+		t.Errorf("CombineMapFunc() = %q, want %q", got, want)
+	}
+}
+
+// validatePort fails for ports outside the unprivileged range.
+func validatePort(name string, port int) error {
+	if port < 1024 || port > 65535 {
+		return fmt.Errorf("%s: port %d out of range", name, port)
+	}
+	return nil
+}
+
+func TestCollectMap(t *testing.T) {
+	ports := map[string]int{"http": 8080, "admin": 80, "grpc": 9090, "debug": 70000}
+
+	err := rxmerr.CollectMap(ports, validatePort)
+	want := []string{"admin: port 80 out of range", "debug: port 70000 out of range"}
+	got := rxmerr.Messages(err)
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Errorf("Messages(CollectMap()) sorted = %q, want %q", got, want)
+	}
+
+	if err := rxmerr.CollectMap(map[string]int{"http": 8080}, validatePort); err != nil {
+		t.Errorf("CollectMap() with valid entries = %v, want nil", err)
+	}
+	if err := rxmerr.CollectMap(map[string]int(nil), validatePort); err != nil {
+		t.Errorf("CollectMap(nil) = %v, want nil", err)
+	}
+}
+
+func TestCollectMapSorted(t *testing.T) {
+	ports := map[string]int{"http": 8080, "admin": 80, "grpc": 9090, "debug": 70000, "alt": 1}
+
+	var visited []string
+	err := rxmerr.CollectMapSorted(ports, func(name string, port int) error {
+		visited = append(visited, name)
+		return validatePort(name, port)
+	})
+	if want := []string{"admin", "alt", "debug", "grpc", "http"}; !slices.Equal(visited, want) {
+		t.Errorf("visited %q, want %q", visited, want)
+	}
+	want := "admin: port 80 out of range; alt: port 1 out of range; debug: port 70000 out of range"
+	if err == nil || err.Error() != want {
+		t.Errorf("CollectMapSorted() = %v, want %q", err, want)
+	}
+
+	if err := rxmerr.CollectMapSorted(map[int]int{1: 8080, 2: 9090}, func(_ int, port int) error {
+		return validatePort("port", port)
+	}); err != nil {
+		t.Errorf("CollectMapSorted() with valid entries = %v, want nil", err)
+	}
+}
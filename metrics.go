@@ -0,0 +1,42 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+// IncCounters reports the collected errors to a metrics counter.
+//
+// For each collected error, in the order returned by Errors, IncCounters
+// computes a label with label and calls inc with it. This decouples the
+// collector from any particular metrics library; for example, with a
+// Prometheus CounterVec:
+//
+//	c.IncCounters(func(l string) {
+//	    errorsTotal.WithLabelValues(l).Inc()
+//	}, func(err error) string {
+//	    if errors.Is(err, context.DeadlineExceeded) {
+//	        return "timeout"
+//	    }
+//	    return "other"
+//	})
+//
+// Repeated errors are reported once per occurrence. If no errors were
+// collected, IncCounters does nothing. The collector is not modified, so
+// calling IncCounters again reports the same errors again.
+func (c *Collector) IncCounters(inc func(label string), label func(error) string) {
+	for _, err := range c.Errors() {
+		inc(label(err))
+	}
+}
@@ -0,0 +1,69 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+// timeoutLabel labels timeouts "timeout" and every other error "other".
+func timeoutLabel(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	return "other"
+}
+
+func TestIncCounters(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(
+		fmt.Errorf("shard 1: %w", context.DeadlineExceeded),
+		errors.New("shard 2: refused"),
+		context.DeadlineExceeded,
+		nil,
+		fmt.Errorf("shard 4: %w", context.DeadlineExceeded),
+	)
+
+	counts := map[string]int{}
+	inc := func(label string) { counts[label]++ }
+	c.IncCounters(inc, timeoutLabel)
+	if want := map[string]int{"timeout": 3, "other": 1}; !maps.Equal(counts, want) {
+		t.Errorf("counts = %v, want %v", counts, want)
+	}
+
+	c.IncCounters(inc, timeoutLabel)
+	if want := map[string]int{"timeout": 6, "other": 2}; !maps.Equal(counts, want) {
+		t.Errorf("counts after a second call = %v, want %v", counts, want)
+	}
+}
+
+func TestIncCountersEmpty(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(nil)
+	c.IncCounters(func(label string) {
+		t.Errorf("inc(%q) called on an empty collector", label)
+	}, func(err error) string {
+		t.Errorf("label(%v) called on an empty collector", err)
+		return ""
+	})
+}
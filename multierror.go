@@ -0,0 +1,114 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MultiError is a concrete aggregate type owned by this package.
+//
+// Aggregates built by Combine and Collector.Err have types defined by
+// go.uber.org/multierr, which callers cannot name. MultiError gives callers a
+// stable type to assert to, while remaining interoperable: it exposes its
+// constituents through Unwrap() []error, so Errors, errors.Is and errors.As
+// work on it like on any other aggregate.
+//
+// A nil *MultiError is a valid, empty aggregate. Note that storing a nil
+// *MultiError in an error variable yields a non-nil error; functions that
+// return error SHOULD check for nil first:
+//
+//	if me := c.ToError(); me != nil {
+//	    return me
+//	}
+//	return nil
+type MultiError struct {
+	errs []error
+}
+
+// NewMultiError returns a MultiError holding the non-nil errs, in order.
+// Constituents of aggregates among errs are not flattened. If all errs are
+// nil, NewMultiError returns nil.
+func NewMultiError(errs ...error) *MultiError {
+	var kept []error
+	for _, err := range errs {
+		if err != nil {
+			kept = append(kept, err)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return &MultiError{errs: kept}
+}
+
+// ToError returns the collected errors, in the order returned by Errors, as a
+// *MultiError, or nil if no errors were collected.
+//
+// Unlike Err, ToError does not apply the context message set with
+// SetContext, and it always returns a *MultiError, even for a single error.
+func (c *Collector) ToError() *MultiError {
+	return NewMultiError(c.Errors()...)
+}
+
+// AsMultiError finds the first *MultiError in the chain of err, as
+// errors.As does, and reports whether one was found.
+func AsMultiError(err error) (*MultiError, bool) {
+	var me *MultiError
+	if errors.As(err, &me) && me != nil {
+		return me, true
+	}
+	return nil, false
+}
+
+// Error returns the messages of the constituents separated by "; ", in the
+// same format as multierr aggregates. It returns an empty string for a nil
+// or empty MultiError.
+func (e *MultiError) Error() string {
+	if e == nil {
+		return ""
+	}
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns a copy of the constituents.
+func (e *MultiError) Unwrap() []error {
+	if e == nil {
+		return nil
+	}
+	return append([]error(nil), e.errs...)
+}
+
+// Format implements fmt.Formatter: %+v lists the constituents, each formatted
+// with %+v, %q quotes Error(), and other verbs write Error().
+func (e *MultiError) Format(f fmt.State, verb rune) {
+	formatAggregate(f, verb, e, e.Unwrap())
+}
+
+// Len returns the number of constituents.
+func (e *MultiError) Len() int {
+	if e == nil {
+		return 0
+	}
+	return len(e.errs)
+}
@@ -0,0 +1,117 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestNewMultiError(t *testing.T) {
+	if me := rxmerr.NewMultiError(); me != nil {
+		t.Errorf("NewMultiError() = %v, want nil", me)
+	}
+	if me := rxmerr.NewMultiError(nil, nil); me != nil {
+		t.Errorf("NewMultiError(nil, nil) = %v, want nil", me)
+	}
+
+	errA := errors.New("a")
+	inner := rxmerr.Combine(io.EOF, io.ErrClosedPipe)
+	me := rxmerr.NewMultiError(errA, nil, inner)
+	if got := me.Unwrap(); !slices.Equal(got, []error{errA, inner}) {
+		t.Errorf("Unwrap() = %v, want [a, inner aggregate] unflattened", got)
+	}
+	if got := me.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+	if got, want := me.Error(), "a; EOF; io: read/write on closed pipe"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(me, io.ErrClosedPipe) {
+		t.Errorf("errors.Is(%v, io.ErrClosedPipe) = false, want true", me)
+	}
+}
+
+func TestMultiErrorNil(t *testing.T) {
+	var me *rxmerr.MultiError
+	if me.Error() != "" || me.Unwrap() != nil || me.Len() != 0 {
+		t.Errorf("nil *MultiError = (%q, %v, %d), want empty", me.Error(), me.Unwrap(), me.Len())
+	}
+}
+
+func TestMultiErrorUnwrapCopy(t *testing.T) {
+	errA := errors.New("a")
+	me := rxmerr.NewMultiError(errA, io.EOF)
+
+	got := me.Unwrap()
+	got[0] = io.ErrClosedPipe
+	if got := me.Unwrap(); !slices.Equal(got, []error{errA, io.EOF}) {
+		t.Errorf("Unwrap() after modifying a previous result = %v, want [a EOF]", got)
+	}
+	if errors.Is(me, io.ErrClosedPipe) {
+		t.Error("errors.Is(me, io.ErrClosedPipe) = true after modifying an Unwrap result")
+	}
+}
+
+func TestToError(t *testing.T) {
+	c := rxmerr.NewCollector()
+	if me := c.ToError(); me != nil {
+		t.Errorf("ToError() on empty collector = %v, want nil", me)
+	}
+
+	c.SetContext("sync routes")
+	c.Append(io.EOF)
+	me := c.ToError()
+	if me == nil || me.Len() != 1 {
+		t.Fatalf("ToError() with one error = %v, want a *MultiError of length 1", me)
+	}
+	if got := me.Error(); got != "EOF" {
+		t.Errorf("ToError().Error() = %q, want the context not applied", got)
+	}
+}
+
+func TestAsMultiError(t *testing.T) {
+	me := rxmerr.NewMultiError(io.EOF)
+	var nilME *rxmerr.MultiError
+
+	tests := []struct {
+		name string
+		err  error
+		want *rxmerr.MultiError
+	}{
+		{name: "nil", err: nil},
+		{name: "plain", err: io.EOF},
+		{name: "multierr aggregate", err: rxmerr.Combine(io.EOF, io.ErrClosedPipe)},
+		{name: "nil pointer", err: nilME},
+		{name: "direct", err: me, want: me},
+		{name: "wrapped", err: fmt.Errorf("sync: %w", me), want: me},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := rxmerr.AsMultiError(tt.err)
+			if got != tt.want || ok != (tt.want != nil) {
+				t.Errorf("AsMultiError() = (%v, %v), want (%v, %v)", got, ok, tt.want, tt.want != nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CombineNetError merges errs like Combine, but returns an aggregate that
+// implements the Timeout and Temporary methods of net.Error, so network code
+// that inspects those methods can handle the aggregate as a whole:
+//
+//	err := rxmerr.CombineNetError(errs...)
+//	var ne net.Error
+//	if errors.As(err, &ne) && ne.Temporary() {
+//	    retry()
+//	}
+//
+// Temporary reports true only if every constituent is temporary, and Timeout
+// reports true only if every constituent is a timeout, as retrying the whole
+// batch is only reasonable if no constituent failed permanently. A
+// constituent counts as temporary (or a timeout) if errors.As finds an error
+// in its chain whose Temporary (or Timeout) method reports true; constituents
+// without such a method count as false.
+//
+// If all errs are nil, CombineNetError returns nil. The constituents of the
+// returned error are available via Errors, errors.Is, and errors.As.
+func CombineNetError(errs ...error) error {
+	err := Combine(errs...)
+	if err == nil {
+		return nil
+	}
+	return &netError{err: err}
+}
+
+// netError decorates an aggregate with the methods of net.Error.
+type netError struct {
+	err error
+}
+
+func (e *netError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the constituents of the decorated aggregate.
+func (e *netError) Unwrap() []error {
+	return Errors(e.err)
+}
+
+// Format implements fmt.Formatter: %+v lists the constituents, each formatted
+// with %+v, %q quotes Error(), and other verbs write Error().
+func (e *netError) Format(f fmt.State, verb rune) {
+	formatAggregate(f, verb, e, e.Unwrap())
+}
+
+// Timeout reports whether every constituent is a timeout.
+func (e *netError) Timeout() bool {
+	return e.all(func(err error) bool {
+		var t interface{ Timeout() bool }
+		return errors.As(err, &t) && t.Timeout()
+	})
+}
+
+// Temporary reports whether every constituent is temporary.
+func (e *netError) Temporary() bool {
+	return e.all(func(err error) bool {
+		var t interface{ Temporary() bool }
+		return errors.As(err, &t) && t.Temporary()
+	})
+}
+
+// all reports whether pred holds for every constituent.
+func (e *netError) all(pred func(error) bool) bool {
+	for _, err := range e.Unwrap() {
+		if !pred(err) {
+			return false
+		}
+	}
+	return true
+}
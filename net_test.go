@@ -0,0 +1,97 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+// netErr is a net.Error with fixed answers.
+type netErr struct {
+	timeout, temporary bool
+}
+
+func (e netErr) Error() string {
+	return fmt.Sprintf("net timeout=%v temporary=%v", e.timeout, e.temporary)
+}
+func (e netErr) Timeout() bool   { return e.timeout }
+func (e netErr) Temporary() bool { return e.temporary }
+
+func TestCombineNetError(t *testing.T) {
+	tempTimeout := netErr{timeout: true, temporary: true}
+	temp := netErr{temporary: true}
+	permanent := netErr{}
+	plain := errors.New("plain")
+
+	tests := []struct {
+		name               string
+		errs               []error
+		timeout, temporary bool
+	}{
+		{"single temporary", []error{temp}, false, true},
+		{"all temporary", []error{tempTimeout, temp}, false, true},
+		{"all timeouts", []error{tempTimeout, tempTimeout}, true, true},
+		{"some permanent", []error{temp, permanent}, false, false},
+		{"plain constituent", []error{tempTimeout, plain}, false, false},
+		{"wrapped", []error{fmt.Errorf("dial: %w", tempTimeout), tempTimeout}, true, true},
+		{"mixed nil", []error{nil, temp, nil}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rxmerr.CombineNetError(tt.errs...)
+			var ne net.Error
+			if !errors.As(err, &ne) {
+				t.Fatalf("errors.As(%v, net.Error) = false", err)
+			}
+			if got := ne.Timeout(); got != tt.timeout {
+				t.Errorf("Timeout() = %v, want %v", got, tt.timeout)
+			}
+			if got := ne.Temporary(); got != tt.temporary {
+				t.Errorf("Temporary() = %v, want %v", got, tt.temporary)
+			}
+			if got, want := err.Error(), rxmerr.Combine(tt.errs...).Error(); got != want {
+				t.Errorf("Error() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCombineNetErrorNil(t *testing.T) {
+	if err := rxmerr.CombineNetError(); err != nil {
+		t.Errorf("CombineNetError() = %v, want nil", err)
+	}
+	if err := rxmerr.CombineNetError(nil, nil); err != nil {
+		t.Errorf("CombineNetError(nil, nil) = %v, want nil", err)
+	}
+}
+
+func TestCombineNetErrorConstituents(t *testing.T) {
+	errA := errors.New("a")
+	err := rxmerr.CombineNetError(errA, io.EOF)
+	if !errors.Is(err, errA) || !errors.Is(err, io.EOF) {
+		t.Errorf("CombineNetError() = %v, constituents not reachable via errors.Is", err)
+	}
+	if got := len(rxmerr.Errors(err)); got != 2 {
+		t.Errorf("len(Errors()) = %d, want 2", got)
+	}
+}
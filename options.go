@@ -0,0 +1,139 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"fmt"
+	"time"
+)
+
+// Option configures a Collector created by NewCollector.
+//
+// # Pipeline order
+//
+// Options install stages of an append-time pipeline. Every non-nil error
+// passed to Append goes through the stages in a fixed order, regardless of
+// the order in which options were given to NewCollector:
+//
+//  1. Ignore: predicates installed by WithIgnore are evaluated; if any
+//     reports true, the error is discarded.
+//  2. Transform: functions installed by WithTransform and TimestampPrefix are
+//     applied, in the order in which the options were given. A transform
+//     that returns nil discards the error.
+//  3. Rate limit: the error takes a token from the bucket installed by
+//     WithRateLimit; if none is available, the error is dropped.
+//  4. Merge: an error that repeats the previous one MAY be merged into the
+//     previous entry, see WithCoalesce.
+//  5. Store: the error is stored and counted by Len.
+//
+// Options that bound the amount of stored data, such as WithMaxBytes, apply
+// last, after merging. Errors discarded by the ignore and transform stages
+// are not counted by Len and do not consume rate limit tokens; errors
+// dropped by bounds are counted.
+type Option func(*Collector)
+
+// CollectorOption is the former name of Option.
+//
+// Deprecated: Use Option.
+type CollectorOption = Option
+
+// WithIgnore returns an option that discards every error for which ignore
+// reports true, before any other option sees it:
+//
+//	c := rxmerr.NewCollector(rxmerr.WithIgnore(func(err error) bool {
+//	    return errors.Is(err, context.Canceled)
+//	}))
+//
+// Multiple WithIgnore options are combined: an error is discarded if any of
+// the predicates reports true. Discarded errors do not consume WithRateLimit
+// tokens.
+func WithIgnore(ignore func(error) bool) Option {
+	return func(c *Collector) {
+		c.ignore = append(c.ignore, ignore)
+	}
+}
+
+// WithTransform returns an option that replaces every error with the result
+// of transform before it is stored.
+//
+// Transforms run after ignore predicates and in the order in which the
+// options were given. If transform returns nil, the error is discarded.
+// Transforms SHOULD wrap rather than replace errors (for example with
+// fmt.Errorf and %w) so that errors.Is and errors.As keep working on the
+// aggregate.
+func WithTransform(transform func(error) error) Option {
+	return func(c *Collector) {
+		c.transforms = append(c.transforms, transform)
+	}
+}
+
+// TimestampPrefix returns an option that prefixes every collected error with
+// the time at which it was appended.
+//
+// Each non-nil error passed to Append becomes:
+//
+//	fmt.Errorf("[%s] %w", clock().Format(format), err)
+//
+// so the original error remains reachable via errors.Is and errors.As. If
+// clock is nil, the collector's clock is used, see WithClock. Injecting a
+// clock is mainly useful for deterministic tests:
+//
+//	c := rxmerr.NewCollector(rxmerr.TimestampPrefix(time.RFC3339, fakeNow))
+//
+// The prefix is a transform stage, see Option.
+func TimestampPrefix(format string, clock func() time.Time) Option {
+	return func(c *Collector) {
+		c.transforms = append(c.transforms, func(err error) error {
+			return fmt.Errorf("[%s] %w", c.timeFrom(clock).Format(format), err)
+		})
+	}
+}
+
+// WithClock returns an option that sets the time source of the collector to
+// clock. The default is time.Now.
+//
+// Every time-based feature of the collector reads the current time from
+// clock, unless it was given a clock of its own: options such as
+// TimestampPrefix and WithCoalesce use clock when their own clock argument is
+// nil. A fake clock makes time-based behavior deterministic in tests:
+//
+//	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+//	c := rxmerr.NewCollector(
+//	    rxmerr.WithClock(func() time.Time { return now }),
+//	    rxmerr.WithCoalesce(time.Second, nil),
+//	)
+//
+// The clock applies regardless of whether WithClock is given before or after
+// the options that use it. A nil clock restores the default.
+func WithClock(clock func() time.Time) Option {
+	return func(c *Collector) {
+		c.clock = clock
+	}
+}
+
+// timeFrom returns the current time according to clock, or according to the
+// collector's clock (see WithClock) if clock is nil.
+func (c *Collector) timeFrom(clock func() time.Time) time.Time {
+	switch {
+	case clock != nil:
+		return clock()
+	case c.clock != nil:
+		return c.clock()
+	default:
+		return time.Now()
+	}
+}
@@ -0,0 +1,199 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"dirpx.dev/rxmerr"
+)
+
+// fakeClock returns a clock that reports *now, so tests can advance time by
+// assigning to now.
+func fakeClock(now *time.Time) func() time.Time {
+	return func() time.Time { return *now }
+}
+
+func TestTimestampPrefix(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.TimestampPrefix(time.RFC3339, fakeClock(&now)))
+
+	c.Append(io.EOF)
+	now = now.Add(90 * time.Second)
+	c.Append(errors.New("disk full"))
+
+	want := []string{
+		"[2025-03-14T15:09:26Z] EOF",
+		"[2025-03-14T15:10:56Z] disk full",
+	}
+	got := c.Messages()
+	if len(got) != len(want) {
+		t.Fatalf("Messages() = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Messages()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if !errors.Is(c.Err(), io.EOF) {
+		t.Error("errors.Is(c.Err(), io.EOF) = false, want true")
+	}
+	if !errors.Is(c.Errors()[0], io.EOF) {
+		t.Error("errors.Is(c.Errors()[0], io.EOF) = false, want true")
+	}
+}
+
+func TestTimestampPrefixUsesCollectorClock(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := rxmerr.NewCollector(
+		rxmerr.TimestampPrefix("15:04:05", nil),
+		rxmerr.WithClock(fakeClock(&now)),
+	)
+	c.Append(io.EOF)
+
+	if got, want := c.Err().Error(), "[08:00:00] EOF"; got != want {
+		t.Errorf("Err() = %q, want %q", got, want)
+	}
+}
+
+func TestTimestampPrefixIgnoresNil(t *testing.T) {
+	now := time.Unix(0, 0)
+	c := rxmerr.NewCollector(rxmerr.TimestampPrefix(time.RFC3339, fakeClock(&now)))
+	c.Append(nil)
+
+	if c.HasError() {
+		t.Errorf("HasError() = true after appending nil: %v", c.Err())
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := rxmerr.NewCollector(
+		rxmerr.WithCoalesce(time.Second, nil),
+		rxmerr.MaxRate(2, nil),
+		rxmerr.WithClock(fakeClock(&now)),
+	)
+
+	c.Append(io.EOF)
+	now = now.Add(500 * time.Millisecond)
+	c.Append(io.EOF)
+	now = now.Add(2 * time.Second)
+	c.Append(io.EOF)
+	c.Append(io.ErrClosedPipe)
+
+	want := []string{
+		"EOF (repeated 1 times over 500ms)",
+		"EOF",
+		"io: read/write on closed pipe",
+	}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+
+	c.Append(io.ErrUnexpectedEOF)
+	if got := c.RateDropped(); got != 1 {
+		t.Errorf("RateDropped() = %d, want 1 within the same second", got)
+	}
+	now = now.Add(time.Second)
+	c.Append(io.ErrUnexpectedEOF)
+	if got := c.Stored(); got != 4 {
+		t.Errorf("Stored() = %d, want 4 after the next second started", got)
+	}
+}
+
+func TestWithClockTTL(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.WithClock(fakeClock(&now)))
+	c.AppendWithTTL(io.EOF, time.Minute)
+	c.Append(io.ErrClosedPipe)
+
+	if got, want := c.NextExpiry(), now.Add(time.Minute); !got.Equal(want) {
+		t.Errorf("NextExpiry() = %v, want %v", got, want)
+	}
+	now = now.Add(59 * time.Second)
+	if got := c.Expire(); got != 0 {
+		t.Errorf("Expire() before the TTL elapsed = %d, want 0", got)
+	}
+	now = now.Add(time.Second)
+	if got := c.Expire(); got != 1 {
+		t.Errorf("Expire() after the TTL elapsed = %d, want 1", got)
+	}
+	if got, want := c.Messages(), []string{"io: read/write on closed pipe"}; !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+}
+
+func TestWithClockNil(t *testing.T) {
+	fixed := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := rxmerr.NewCollector(
+		rxmerr.TimestampPrefix("2006", nil),
+		rxmerr.WithClock(fakeClock(&fixed)),
+		rxmerr.WithClock(nil),
+	)
+	c.Append(io.EOF)
+
+	if got, old := c.Err().Error(), "[2000] EOF"; got == old {
+		t.Errorf("Err() = %q, want the time from time.Now after WithClock(nil)", got)
+	}
+}
+
+func TestOptionPipelineOrder(t *testing.T) {
+	var ignored []string
+	ignoreEOF := func(err error) bool {
+		ignored = append(ignored, err.Error())
+		return errors.Is(err, io.EOF)
+	}
+	wrap := func(label string) func(error) error {
+		return func(err error) error { return fmt.Errorf("%s: %w", label, err) }
+	}
+
+	// Options are deliberately given out of pipeline order.
+	c := rxmerr.NewCollector(
+		rxmerr.WithMaxBytes(len("outer: inner: disk full (repeated 1 times)")),
+		rxmerr.CollapseConsecutive(),
+		rxmerr.WithTransform(wrap("inner")),
+		rxmerr.WithIgnore(ignoreEOF),
+		rxmerr.WithTransform(wrap("outer")),
+	)
+
+	c.Append(io.EOF, errors.New("disk full"), errors.New("disk full"), errors.New("quota exceeded"))
+
+	// Ignore runs first and sees the errors as appended.
+	wantIgnored := []string{"EOF", "disk full", "disk full", "quota exceeded"}
+	if !slices.Equal(ignored, wantIgnored) {
+		t.Errorf("ignore saw %q, want %q", ignored, wantIgnored)
+	}
+	// Transforms run in option order, merging sees transformed messages, and
+	// the byte bound applies last, after the repeat was merged.
+	want := []string{"outer: inner: disk full (repeated 1 times)"}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	// The ignored error is not counted; the one dropped by the bound is.
+	if got := c.Len(); got != 3 {
+		t.Errorf("Len() = %d, want 3", got)
+	}
+	if got, want := c.DroppedBytes(), len("outer: inner: quota exceeded"); got != want {
+		t.Errorf("DroppedBytes() = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,52 @@
+//go:build rxmerr_otel
+
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecordTo records the collected errors on span.
+//
+// Every error returned by Errors is recorded as a separate span event with
+// span.RecordError, receiving opts, in collection order. If at least one
+// error was collected, the span status is then set to codes.Error with the
+// message of the aggregate returned by Err:
+//
+//	ctx, span := tracer.Start(ctx, "sync")
+//	defer span.End()
+//	...
+//	c.RecordTo(span)
+//
+// If no errors were collected, RecordTo does not touch span, so a status set
+// elsewhere is preserved.
+//
+// This method is only available when building with the rxmerr_otel build
+// tag.
+func (c *Collector) RecordTo(span trace.Span, opts ...trace.EventOption) {
+	errs := c.Errors()
+	if len(errs) == 0 {
+		return
+	}
+	for _, err := range errs {
+		span.RecordError(err, opts...)
+	}
+	span.SetStatus(codes.Error, c.Err().Error())
+}
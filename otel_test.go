@@ -0,0 +1,75 @@
+//go:build rxmerr_otel
+
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"dirpx.dev/rxmerr"
+)
+
+// fakeSpan records the calls made by RecordTo. Methods not overridden panic
+// through the nil embedded trace.Span.
+type fakeSpan struct {
+	trace.Span
+	recorded   []error
+	statusSet  bool
+	statusCode codes.Code
+	statusDesc string
+}
+
+func (s *fakeSpan) RecordError(err error, _ ...trace.EventOption) {
+	s.recorded = append(s.recorded, err)
+}
+
+func (s *fakeSpan) SetStatus(code codes.Code, description string) {
+	s.statusSet = true
+	s.statusCode = code
+	s.statusDesc = description
+}
+
+func TestRecordTo(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	c := rxmerr.NewCollector()
+	c.Append(errA, errB)
+
+	span := &fakeSpan{}
+	c.RecordTo(span)
+
+	if !slices.Equal(span.recorded, []error{errA, errB}) {
+		t.Errorf("RecordError called with %v, want [a b]", span.recorded)
+	}
+	if !span.statusSet || span.statusCode != codes.Error || span.statusDesc != "a; b" {
+		t.Errorf("SetStatus(%v, %q), want (Error, %q)", span.statusCode, span.statusDesc, "a; b")
+	}
+}
+
+func TestRecordToEmpty(t *testing.T) {
+	span := &fakeSpan{}
+	rxmerr.NewCollector().RecordTo(span)
+
+	if len(span.recorded) != 0 || span.statusSet {
+		t.Errorf("RecordTo on empty collector recorded %v, status set %v; want no calls", span.recorded, span.statusSet)
+	}
+}
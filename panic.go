@@ -0,0 +1,136 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is an error converted from a recovered panic value by FromPanic.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+
+	// Stack is the stack trace of the panicking goroutine, as captured by
+	// runtime/debug.Stack when the value was converted.
+	Stack []byte
+}
+
+// Error returns "panic: " followed by the panic value: its Error() string if
+// it is an error, its %v formatting otherwise.
+func (e *PanicError) Error() string {
+	if err, ok := e.Value.(error); ok {
+		return "panic: " + err.Error()
+	}
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, or nil otherwise.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Format implements fmt.Formatter: %+v writes the message, formatting an
+// error panic value with %+v, followed by the stack trace; %q quotes Error(),
+// and other verbs write Error().
+func (e *PanicError) Format(f fmt.State, verb rune) {
+	if verb != 'v' || !f.Flag('+') {
+		formatCompact(f, verb, e)
+		return
+	}
+	if err := e.Unwrap(); err != nil {
+		fmt.Fprintf(f, "panic: %+v", err)
+	} else {
+		fmt.Fprintf(f, "panic: %v", e.Value)
+	}
+	if len(e.Stack) > 0 {
+		fmt.Fprintf(f, "\n\n%s", e.Stack)
+	}
+}
+
+// FromPanic converts v, a value returned by recover, into a *PanicError that
+// records the value and the current stack trace:
+//
+//	defer func() {
+//	    if v := recover(); v != nil {
+//	        rxmerr.AppendToCtx(r.Context(), rxmerr.FromPanic(v))
+//	        w.WriteHeader(http.StatusInternalServerError)
+//	    }
+//	}()
+//
+// FromPanic SHOULD be called from the deferred function that recovered v,
+// so that the stack trace shows where the panic happened. An error value
+// stays reachable via errors.Is and errors.As; other values, such as strings
+// or structs, are rendered with %v. If v is nil, FromPanic returns nil; note
+// that recover reports panic(nil) as a *runtime.PanicNilError, which is
+// converted like any other error.
+func FromPanic(v any) error {
+	if v == nil {
+		return nil
+	}
+	return &PanicError{Value: v, Stack: debug.Stack()}
+}
+
+// AppendPanicValue appends FromPanic(v) to the collector. If v is nil,
+// nothing is appended.
+func (c *Collector) AppendPanicValue(v any) {
+	c.Append(FromPanic(v))
+}
+
+// IsPanic reports whether err, or any error in its tree, is a *PanicError,
+// for example to page on-call staff whatever other errors an aggregate
+// contains.
+func IsPanic(err error) bool {
+	_, ok := PanicValue(err)
+	return ok
+}
+
+// PanicValue returns the panic value of the first *PanicError in the tree of
+// err, as found by errors.As, and reports whether there is one.
+func PanicValue(err error) (any, bool) {
+	var pe *PanicError
+	if errors.As(err, &pe) {
+		return pe.Value, true
+	}
+	return nil, false
+}
+
+// DoWithRecover runs fn and appends any panic raised by fn to the collector
+// as an error.
+//
+// This is intended for best-effort work that may panic but should not take
+// down the caller:
+//
+//	for _, hook := range shutdownHooks {
+//	    c.DoWithRecover(hook)
+//	}
+//
+// The panic value is appended as a *PanicError, see FromPanic: if it is an
+// error, it is wrapped as "panic: <err>" and stays reachable via errors.Is
+// and errors.As; other values are formatted with %v. If fn returns normally,
+// nothing is appended.
+func (c *Collector) DoWithRecover(fn func()) {
+	defer func() {
+		if v := recover(); v != nil {
+			c.Append(FromPanic(v))
+		}
+	}()
+	fn()
+}
@@ -0,0 +1,182 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestDoWithRecover(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func()
+		wantMsg string
+		wantIs  error
+	}{
+		{name: "normal", fn: func() {}},
+		{name: "string panic", fn: func() { panic("boom") }, wantMsg: "panic: boom"},
+		{name: "error panic", fn: func() { panic(io.ErrClosedPipe) }, wantMsg: "panic: io: read/write on closed pipe", wantIs: io.ErrClosedPipe},
+		{name: "runtime error", fn: func() {
+			var m map[string]int
+			m["x"] = 1
+		}, wantMsg: "panic: assignment to entry in nil map"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := rxmerr.NewCollector()
+			c.DoWithRecover(tt.fn)
+
+			if tt.wantMsg == "" {
+				if c.HasError() {
+					t.Errorf("Err() = %v, want nil", c.Err())
+				}
+				return
+			}
+			err := c.Err()
+			if err == nil || err.Error() != tt.wantMsg {
+				t.Fatalf("Err() = %v, want %q", err, tt.wantMsg)
+			}
+			var pe *rxmerr.PanicError
+			if !errors.As(err, &pe) || len(pe.Stack) == 0 {
+				t.Errorf("Err() = %v, want a *PanicError with a stack", err)
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Errorf("errors.Is(err, %v) = false, want true", tt.wantIs)
+			}
+		})
+	}
+}
+
+func TestDoWithRecoverContinues(t *testing.T) {
+	c := rxmerr.NewCollector()
+	ran := 0
+	for range 3 {
+		c.DoWithRecover(func() {
+			ran++
+			panic("boom")
+		})
+	}
+	if ran != 3 || c.Len() != 3 {
+		t.Errorf("ran = %d, Len() = %d, want 3 and 3", ran, c.Len())
+	}
+}
+
+func TestFromPanic(t *testing.T) {
+	type payload struct {
+		Code int
+		Op   string
+	}
+	tests := []struct {
+		name    string
+		value   any
+		wantMsg string
+		wantIs  error
+	}{
+		{name: "error", value: io.ErrClosedPipe, wantMsg: "panic: io: read/write on closed pipe", wantIs: io.ErrClosedPipe},
+		{name: "string", value: "boom", wantMsg: "panic: boom"},
+		{name: "struct", value: payload{Code: 7, Op: "flush"}, wantMsg: "panic: {7 flush}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rxmerr.FromPanic(tt.value)
+			if err == nil || err.Error() != tt.wantMsg {
+				t.Fatalf("FromPanic(%#v) = %v, want %q", tt.value, err, tt.wantMsg)
+			}
+			var pe *rxmerr.PanicError
+			if !errors.As(err, &pe) || len(pe.Stack) == 0 {
+				t.Errorf("FromPanic(%#v) = %#v, want a *PanicError with a stack", tt.value, err)
+			}
+			if got := errors.Unwrap(err); got != tt.wantIs {
+				t.Errorf("errors.Unwrap(FromPanic(%#v)) = %v, want %v", tt.value, got, tt.wantIs)
+			}
+			if got, ok := rxmerr.PanicValue(err); !ok || got != tt.value {
+				t.Errorf("PanicValue() = (%v, %v), want (%v, true)", got, ok, tt.value)
+			}
+			if !rxmerr.IsPanic(err) {
+				t.Errorf("IsPanic(%v) = false, want true", err)
+			}
+		})
+	}
+}
+
+func TestFromPanicNil(t *testing.T) {
+	if err := rxmerr.FromPanic(nil); err != nil {
+		t.Errorf("FromPanic(nil) = %v, want nil", err)
+	}
+
+	c := rxmerr.NewCollector()
+	c.AppendPanicValue(nil)
+	if c.HasError() {
+		t.Errorf("Err() after AppendPanicValue(nil) = %v, want nil", c.Err())
+	}
+}
+
+func TestAppendPanicValue(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(io.EOF)
+	func() {
+		defer func() { c.AppendPanicValue(recover()) }()
+		panic("boom")
+	}()
+
+	err := c.Err()
+	if got, want := rxmerr.Messages(err), []string{"EOF", "panic: boom"}; !slices.Equal(got, want) {
+		t.Errorf("Messages(Err()) = %q, want %q", got, want)
+	}
+	if !rxmerr.IsPanic(err) {
+		t.Errorf("IsPanic(%v) = false, want true for an aggregate containing a panic", err)
+	}
+	if got, ok := rxmerr.PanicValue(err); !ok || got != "boom" {
+		t.Errorf("PanicValue() = (%v, %v), want (boom, true)", got, ok)
+	}
+}
+
+func TestIsPanicWithoutPanic(t *testing.T) {
+	for _, err := range []error{nil, io.EOF, rxmerr.Combine(io.EOF, errors.New("a"))} {
+		if rxmerr.IsPanic(err) {
+			t.Errorf("IsPanic(%v) = true, want false", err)
+		}
+		if got, ok := rxmerr.PanicValue(err); ok || got != nil {
+			t.Errorf("PanicValue(%v) = (%v, %v), want (nil, false)", err, got, ok)
+		}
+	}
+}
+
+func TestPanicErrorFormat(t *testing.T) {
+	err := &rxmerr.PanicError{Value: &stackErr{"a"}, Stack: []byte("goroutine 1 [running]:")}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "%v", want: "panic: a"},
+		{format: "%q", want: `"panic: a"`},
+		{format: "%+v", want: "panic: a\nmain.run\n\tmain.go:12\n\ngoroutine 1 [running]:"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, err); got != tt.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+// pipe is a forwarding link from one collector to another, see Pipe.
+type pipe struct {
+	dst       *Collector
+	transform func(error) error // nil forwards errors unchanged
+}
+
+// Pipe configures c to forward every error appended from now on to dst, after
+// passing it through transform, and returns c to allow chaining.
+//
+// Errors are forwarded once they have passed through c's own pipeline, that
+// is, after c's options have been applied. transform MAY return nil to
+// suppress forwarding of a particular error; if transform is nil, errors are
+// forwarded unchanged. Forwarded errors then go through dst's own pipeline,
+// and dst forwards them further if it is piped itself:
+//
+//	all := rxmerr.NewCollector()
+//	severe := rxmerr.NewCollector()
+//	all.Pipe(severe, func(err error) error {
+//	    if severityOf(err) < SeverityHigh {
+//	        return nil
+//	    }
+//	    return err
+//	})
+//
+// Each call adds another destination, so repeated calls create a fan-out in
+// which every destination receives its own transformation of the error, in
+// the order in which Pipe was called. Errors appended before Pipe was called
+// are not forwarded. Collectors MUST NOT be piped into a cycle.
+func (c *Collector) Pipe(dst *Collector, transform func(error) error) *Collector {
+	c.pipes = append(c.pipes, pipe{dst: dst, transform: transform})
+	return c
+}
+
+// forward passes a prepared error with its weight to every piped collector.
+func (c *Collector) forward(err error, weight int) {
+	for _, p := range c.pipes {
+		e := err
+		if p.transform != nil {
+			if e = p.transform(e); e == nil {
+				continue
+			}
+		}
+		p.dst.add(e, weight)
+	}
+}
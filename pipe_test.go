@@ -0,0 +1,135 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestPipeFanOut(t *testing.T) {
+	var order []string
+	src := rxmerr.NewCollector()
+	dsts := make([]*rxmerr.Collector, 3)
+	for i := range dsts {
+		dsts[i] = rxmerr.NewCollector()
+		src.Pipe(dsts[i], func(err error) error {
+			order = append(order, fmt.Sprintf("dst %d: %v", i, err))
+			return fmt.Errorf("dst %d: %w", i, err)
+		})
+	}
+
+	src.Append(io.EOF)
+	if want := []string{"dst 0: EOF", "dst 1: EOF", "dst 2: EOF"}; !slices.Equal(order, want) {
+		t.Errorf("transforms called %q, want %q", order, want)
+	}
+	for i, dst := range dsts {
+		want := []string{fmt.Sprintf("dst %d: EOF", i)}
+		if got := dst.Messages(); !slices.Equal(got, want) {
+			t.Errorf("destination %d: Messages() = %q, want %q", i, got, want)
+		}
+		if !errors.Is(dst.Err(), io.EOF) {
+			t.Errorf("destination %d: errors.Is(Err(), io.EOF) = false", i)
+		}
+	}
+	if got := src.Messages(); !slices.Equal(got, []string{"EOF"}) {
+		t.Errorf("source Messages() = %q, want the untransformed error", got)
+	}
+}
+
+func TestPipeSuppress(t *testing.T) {
+	errMinor := errors.New("minor")
+	src := rxmerr.NewCollector()
+	severe := rxmerr.NewCollector()
+	all := rxmerr.NewCollector()
+	src.Pipe(severe, func(err error) error {
+		if errors.Is(err, errMinor) {
+			return nil
+		}
+		return err
+	}).Pipe(all, nil)
+
+	src.Append(errMinor, io.EOF)
+	if got := severe.Errors(); !slices.Equal(got, []error{io.EOF}) {
+		t.Errorf("severe Errors() = %v, want [EOF]", got)
+	}
+	if got := all.Errors(); !slices.Equal(got, []error{errMinor, io.EOF}) {
+		t.Errorf("all Errors() = %v, want [minor EOF]", got)
+	}
+}
+
+func TestPipeAfterSourcePipeline(t *testing.T) {
+	src := rxmerr.NewCollector(
+		rxmerr.WithIgnore(func(err error) bool { return errors.Is(err, io.EOF) }),
+		rxmerr.WithTransform(func(err error) error { return fmt.Errorf("src: %w", err) }),
+	)
+	dst := rxmerr.NewCollector(rxmerr.WithTransform(func(err error) error {
+		return fmt.Errorf("dst: %w", err)
+	}))
+	src.Pipe(dst, nil)
+
+	src.Append(errors.New("before"))
+	src.Append(io.EOF, io.ErrUnexpectedEOF)
+
+	if got, want := dst.Messages(), []string{"dst: src: before", "dst: src: unexpected EOF"}; !slices.Equal(got, want) {
+		t.Errorf("dst Messages() = %q, want %q", got, want)
+	}
+}
+
+func TestPipeNotRetroactive(t *testing.T) {
+	src := rxmerr.NewCollector()
+	src.Append(io.EOF)
+	dst := rxmerr.NewCollector()
+	src.Pipe(dst, nil)
+
+	if dst.HasError() {
+		t.Errorf("dst Err() = %v, want errors appended before Pipe not forwarded", dst.Err())
+	}
+}
+
+func TestPipeCoalescedWeight(t *testing.T) {
+	src := rxmerr.NewCollector(rxmerr.CollapseConsecutive())
+	dst := rxmerr.NewCollector()
+	src.Pipe(dst, nil)
+
+	for range 3 {
+		src.AppendWeighted(2, errors.New("upstream unavailable"))
+	}
+
+	if got := src.Stored(); got != 1 {
+		t.Errorf("source Stored() = %d, want 1 coalesced entry", got)
+	}
+	for name, c := range map[string]*rxmerr.Collector{"source": src, "dst": dst} {
+		if got := c.Len(); got != 3 {
+			t.Errorf("%s Len() = %d, want 3", name, got)
+		}
+		if got := c.TotalWeight(); got != 6 {
+			t.Errorf("%s TotalWeight() = %d, want 6", name, got)
+		}
+	}
+	for _, msg := range dst.Messages() {
+		if strings.Contains(msg, "repeated") {
+			t.Errorf("dst Messages() contains %q, want every occurrence forwarded as is", msg)
+		}
+	}
+}
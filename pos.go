@@ -0,0 +1,173 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Pos is a position in a source file.
+type Pos struct {
+	File string
+	Line int // 1-based; 0 if unknown
+	Col  int // 1-based; 0 if unknown
+}
+
+// String returns the position in the conventional "file:line:col" form,
+// omitting the column (and then the line) when it is unknown.
+func (p Pos) String() string {
+	s := p.File
+	if p.Line > 0 {
+		s += ":" + strconv.Itoa(p.Line)
+		if p.Col > 0 {
+			s += ":" + strconv.Itoa(p.Col)
+		}
+	}
+	return s
+}
+
+// compare orders positions by file, then line, then column.
+func (p Pos) compare(q Pos) int {
+	return cmp.Or(
+		cmp.Compare(p.File, q.File),
+		cmp.Compare(p.Line, q.Line),
+		cmp.Compare(p.Col, q.Col),
+	)
+}
+
+// PosError is an error annotated with the position at which it occurred.
+//
+// Tools such as editors and language servers can recover the position from
+// an aggregate with errors.As:
+//
+//	var perr *rxmerr.PosError
+//	if errors.As(err, &perr) {
+//	    highlight(perr.Pos)
+//	}
+type PosError struct {
+	Pos Pos
+	Err error
+}
+
+// Error returns the position followed by the message, for example
+// "routes.yaml:12:5: unknown field".
+func (e *PosError) Error() string {
+	return e.Pos.String() + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *PosError) Unwrap() error {
+	return e.Err
+}
+
+// Format implements fmt.Formatter: %+v writes the position followed by the
+// %+v output of the underlying error, %q quotes Error(), and other verbs
+// write Error().
+func (e *PosError) Format(f fmt.State, verb rune) {
+	formatWrapper(f, verb, e, e.Pos.String()+": ", e.Err)
+}
+
+// PosCollector accumulates errors annotated with source positions, as
+// produced by parsers and validators that report every problem in a file
+// rather than only the first one.
+//
+//	pc := rxmerr.NewPosCollector()
+//	pc.AppendAt(rxmerr.Pos{File: name, Line: 3, Col: 7}, errUnknownKey)
+//	return pc.Err()
+//
+// Regardless of the order in which errors were appended, Err and Errors list
+// them sorted by position (file, line, column). Errors at equal positions
+// keep the order in which they were appended.
+//
+// Like Collector, PosCollector is NOT safe for concurrent use. The zero value
+// is an empty PosCollector ready for use.
+type PosCollector struct {
+	errs []*PosError // in collection order
+}
+
+// NewPosCollector creates a new, empty PosCollector.
+func NewPosCollector() *PosCollector {
+	return &PosCollector{}
+}
+
+// AppendAt adds err, annotated with pos as a *PosError. If err is nil,
+// AppendAt does nothing.
+func (pc *PosCollector) AppendAt(pos Pos, err error) {
+	if err != nil {
+		callDebugHook(err)
+		pc.errs = append(pc.errs, &PosError{Pos: pos, Err: err})
+	}
+}
+
+// Len returns the number of collected errors.
+func (pc *PosCollector) Len() int {
+	return len(pc.errs)
+}
+
+// HasError reports whether at least one error has been collected.
+func (pc *PosCollector) HasError() bool {
+	return len(pc.errs) > 0
+}
+
+// Errors returns the collected errors, each a *PosError, sorted by position.
+// If no errors were collected, Errors returns nil.
+func (pc *PosCollector) Errors() []error {
+	if len(pc.errs) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(pc.errs)
+	slices.SortStableFunc(sorted, func(a, b *PosError) int {
+		return a.Pos.compare(b.Pos)
+	})
+
+	errs := make([]error, len(sorted))
+	for i, perr := range sorted {
+		errs[i] = perr
+	}
+	return errs
+}
+
+// Err returns the aggregate of the collected errors sorted by position, or
+// nil if no errors were collected.
+func (pc *PosCollector) Err() error {
+	return Combine(pc.Errors()...)
+}
+
+// ByFile groups the collected errors by Pos.File. Within each file, errors
+// are sorted by position. If no errors were collected, ByFile returns nil.
+func (pc *PosCollector) ByFile() map[string][]error {
+	errs := pc.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+
+	out := make(map[string][]error)
+	for _, err := range errs {
+		file := err.(*PosError).Pos.File
+		out[file] = append(out[file], err)
+	}
+	return out
+}
+
+// Reset clears all collected errors.
+func (pc *PosCollector) Reset() {
+	pc.errs = nil
+}
@@ -0,0 +1,88 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestPosCollectorSorted(t *testing.T) {
+	pc := rxmerr.NewPosCollector()
+	pc.AppendAt(rxmerr.Pos{File: "b.yaml", Line: 2}, errors.New("b2"))
+	pc.AppendAt(rxmerr.Pos{File: "a.yaml", Line: 10, Col: 1}, errors.New("a10"))
+	pc.AppendAt(rxmerr.Pos{File: "a.yaml", Line: 2, Col: 5}, errors.New("a2:5"))
+	pc.AppendAt(rxmerr.Pos{File: "a.yaml", Line: 2, Col: 3}, errors.New("first at a2:3"))
+	pc.AppendAt(rxmerr.Pos{File: "a.yaml", Line: 2, Col: 3}, nil)
+	pc.AppendAt(rxmerr.Pos{File: "a.yaml", Line: 2, Col: 3}, errors.New("second at a2:3"))
+	pc.AppendAt(rxmerr.Pos{File: "a.yaml"}, errors.New("whole file"))
+
+	want := []string{
+		"a.yaml: whole file",
+		"a.yaml:2:3: first at a2:3",
+		"a.yaml:2:3: second at a2:3",
+		"a.yaml:2:5: a2:5",
+		"a.yaml:10:1: a10",
+		"b.yaml:2: b2",
+	}
+	if got := rxmerr.Messages(pc.Err()); !slices.Equal(got, want) {
+		t.Errorf("Messages(Err()) = %q, want %q", got, want)
+	}
+	if got := pc.Len(); got != 6 {
+		t.Errorf("Len() = %d, want 6", got)
+	}
+}
+
+func TestPosCollectorByFile(t *testing.T) {
+	pc := rxmerr.NewPosCollector()
+	if got := pc.ByFile(); got != nil {
+		t.Errorf("ByFile() on empty collector = %v, want nil", got)
+	}
+
+	pc.AppendAt(rxmerr.Pos{File: "b.yaml", Line: 9}, errors.New("b9"))
+	pc.AppendAt(rxmerr.Pos{File: "a.yaml", Line: 3}, errors.New("a3"))
+	pc.AppendAt(rxmerr.Pos{File: "b.yaml", Line: 1}, errors.New("b1"))
+
+	byFile := pc.ByFile()
+	if len(byFile) != 2 {
+		t.Fatalf("ByFile() has %d files, want 2", len(byFile))
+	}
+	if got, want := rxmerr.Messages(rxmerr.Combine(byFile["b.yaml"]...)), []string{"b.yaml:1: b1", "b.yaml:9: b9"}; !slices.Equal(got, want) {
+		t.Errorf("ByFile()[b.yaml] = %q, want %q", got, want)
+	}
+}
+
+func TestPosErrorAs(t *testing.T) {
+	errUnknown := errors.New("unknown field")
+	pc := rxmerr.NewPosCollector()
+	pc.AppendAt(rxmerr.Pos{File: "routes.yaml", Line: 12, Col: 5}, errUnknown)
+
+	err := rxmerr.Combine(errors.New("other"), pc.Err())
+	var perr *rxmerr.PosError
+	if !errors.As(err, &perr) {
+		t.Fatalf("errors.As(%v, *PosError) = false, want true", err)
+	}
+	if want := (rxmerr.Pos{File: "routes.yaml", Line: 12, Col: 5}); perr.Pos != want {
+		t.Errorf("Pos = %v, want %v", perr.Pos, want)
+	}
+	if !errors.Is(err, errUnknown) {
+		t.Errorf("errors.Is(err, errUnknown) = false, want true")
+	}
+}
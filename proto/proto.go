@@ -0,0 +1,68 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package proto converts rxmerr collectors to and from a protobuf message, so
+// that collected errors can be passed across gRPC boundaries.
+//
+// The wire format is the ErrorCollection message defined in errors.proto.
+// Errors are transported as their Error() strings only: the concrete types of
+// the original errors, their wrapped causes, and any identity used by
+// errors.Is or errors.As are lost in the round trip. On the receiving side,
+// every message becomes a plain error created with errors.New.
+package proto
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative errors.proto
+
+import (
+	"errors"
+
+	"dirpx.dev/rxmerr"
+)
+
+// ToProto converts the errors collected by c into an ErrorCollection.
+//
+// Messages are taken from c.Errors in order, and Count is set to c.Len(). If
+// c is nil, ToProto returns nil.
+func ToProto(c *rxmerr.Collector) *ErrorCollection {
+	if c == nil {
+		return nil
+	}
+
+	errs := c.Errors()
+	p := &ErrorCollection{
+		Messages: make([]string, len(errs)),
+		Count:    int32(c.Len()),
+	}
+	for i, err := range errs {
+		p.Messages[i] = err.Error()
+	}
+	return p
+}
+
+// FromProto reconstructs a Collector from an ErrorCollection.
+//
+// Each message is appended to a new Collector as errors.New(message), so the
+// resulting collector has one error per message; the Count field is
+// informational and is not used to fabricate additional errors. Type
+// information of the original errors is not recoverable. If p is nil,
+// FromProto returns an empty Collector.
+func FromProto(p *ErrorCollection) *rxmerr.Collector {
+	c := rxmerr.NewCollector()
+	for _, msg := range p.GetMessages() {
+		c.Append(errors.New(msg))
+	}
+	return c
+}
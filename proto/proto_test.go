@@ -0,0 +1,97 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package proto_test
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	protobuf "google.golang.org/protobuf/proto"
+
+	"dirpx.dev/rxmerr"
+	"dirpx.dev/rxmerr/proto"
+)
+
+func TestRoundTrip(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(rxmerr.WithCode(errors.New("db unavailable"), "DB_UNAVAILABLE"), io.EOF)
+
+	p := proto.ToProto(c)
+	if want := []string{"db unavailable", "EOF"}; !slices.Equal(p.GetMessages(), want) {
+		t.Errorf("ToProto().Messages = %q, want %q", p.GetMessages(), want)
+	}
+	if got := p.GetCount(); got != 2 {
+		t.Errorf("ToProto().Count = %d, want 2", got)
+	}
+
+	b, err := protobuf.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var wire proto.ErrorCollection
+	if err := protobuf.Unmarshal(b, &wire); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	got := proto.FromProto(&wire)
+	if !slices.Equal(got.Messages(), c.Messages()) {
+		t.Errorf("FromProto().Messages() = %q, want %q", got.Messages(), c.Messages())
+	}
+	if got.Len() != 2 {
+		t.Errorf("FromProto().Len() = %d, want 2", got.Len())
+	}
+	if _, ok := rxmerr.CodeOf(got.Err()); ok {
+		t.Error("CodeOf(FromProto().Err()) found a code, want codes lost in transit")
+	}
+	if errors.Is(got.Err(), io.EOF) {
+		t.Error("errors.Is(FromProto().Err(), io.EOF) = true, want identities lost in transit")
+	}
+}
+
+func TestToProtoCount(t *testing.T) {
+	c := rxmerr.NewCollector(rxmerr.CollapseConsecutive())
+	for range 3 {
+		c.Append(errors.New("upstream unavailable"))
+	}
+
+	p := proto.ToProto(c)
+	if got := p.GetCount(); got != 3 {
+		t.Errorf("ToProto().Count = %d, want 3", got)
+	}
+	if got := len(p.GetMessages()); got != 1 {
+		t.Errorf("len(ToProto().Messages) = %d, want 1 coalesced entry", got)
+	}
+	if got := proto.FromProto(p).Len(); got != 1 {
+		t.Errorf("FromProto().Len() = %d, want 1: Count does not fabricate errors", got)
+	}
+}
+
+func TestNil(t *testing.T) {
+	if p := proto.ToProto(nil); p != nil {
+		t.Errorf("ToProto(nil) = %v, want nil", p)
+	}
+	if c := proto.FromProto(nil); c == nil || c.HasError() {
+		t.Errorf("FromProto(nil) = %v, want an empty collector", c)
+	}
+
+	p := proto.ToProto(rxmerr.NewCollector())
+	if len(p.GetMessages()) != 0 || p.GetCount() != 0 {
+		t.Errorf("ToProto(empty) = %v, want no messages and a zero count", p)
+	}
+}
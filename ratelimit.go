@@ -0,0 +1,201 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxRateConfig holds the settings and state of MaxRate.
+type maxRateConfig struct {
+	perSecond int
+	clock     func() time.Time // nil to use the collector's clock
+	second    time.Time        // start of the current wall-clock second
+	stored    int              // errors stored within second
+	dropped   int              // errors dropped since the last Reset
+}
+
+// MaxRate returns an option that stores at most perSecond errors per
+// wall-clock second, protecting memory and logs under error floods.
+//
+// Errors beyond the limit are dropped: they are still counted by Len, but are
+// not stored, and their number is reported by RateDropped. While errors have
+// been dropped, the aggregate built by the collector ends with a note such as
+//
+//	rxmerr: dropped 1520 errors due to rate limit
+//
+// so the loss is visible wherever the aggregate is reported. The note is not
+// counted by Len or Stored.
+//
+// Seconds are delimited by truncating the time read from clock to whole
+// seconds; if clock is nil, the collector's clock is used, see WithClock. The
+// limit is a storage bound that applies after merging, see Option. A
+// non-positive perSecond disables the limit.
+func MaxRate(perSecond int, clock func() time.Time) Option {
+	return func(c *Collector) {
+		if perSecond <= 0 {
+			c.maxRate = nil
+			return
+		}
+		c.maxRate = &maxRateConfig{perSecond: perSecond, clock: clock}
+	}
+}
+
+// RateDropped returns the number of errors dropped because of MaxRate since
+// the collector was created or last Reset.
+func (c *Collector) RateDropped() int {
+	if c.maxRate == nil {
+		return 0
+	}
+	return c.maxRate.dropped
+}
+
+// fitsMaxRate reports whether another error may be stored within the MaxRate
+// limit and accounts for it.
+func (c *Collector) fitsMaxRate() bool {
+	r := c.maxRate
+	if r == nil {
+		return true
+	}
+
+	if second := c.timeFrom(r.clock).Truncate(time.Second); !second.Equal(r.second) {
+		r.second, r.stored = second, 0
+	}
+	if r.stored >= r.perSecond {
+		r.dropped++
+		c.err = nil
+		return false
+	}
+	r.stored++
+	return true
+}
+
+// rateNote returns the note appended to the aggregate while errors are
+// dropped because of MaxRate, or nil.
+func (c *Collector) rateNote() error {
+	if n := c.RateDropped(); n > 0 {
+		return fmt.Errorf("rxmerr: dropped %d errors due to rate limit", n)
+	}
+	return nil
+}
+
+// resetMaxRate clears the state of MaxRate, keeping its settings.
+func (c *Collector) resetMaxRate() {
+	if c.maxRate != nil {
+		*c.maxRate = maxRateConfig{perSecond: c.maxRate.perSecond, clock: c.maxRate.clock}
+	}
+}
+
+// RateLimitedError is appended by a collector created with WithRateLimit
+// when it starts dropping errors. Its zero value is a sentinel that can be
+// matched with errors.Is:
+//
+//	errors.Is(c.Err(), rxmerr.RateLimitedError{})
+type RateLimitedError struct{}
+
+func (RateLimitedError) Error() string {
+	return "rxmerr: errors dropped due to rate limit"
+}
+
+// rateLimitConfig holds the settings and state of WithRateLimit.
+type rateLimitConfig struct {
+	rate     float64   // tokens added per second
+	burst    int       // capacity of the bucket
+	tokens   float64   // tokens currently in the bucket
+	last     time.Time // time tokens was last updated; zero before first use
+	dropped  int       // errors dropped since the last Reset
+	notified bool      // whether RateLimitedError was appended since the bucket ran empty
+}
+
+// WithRateLimit returns an option that limits appends with a token bucket
+// holding up to burst tokens and refilled at rate tokens per second, which
+// bounds the work done by collectors in hot paths.
+//
+// Every non-nil error that passes the ignore and transform stages consumes a
+// token before it is merged or stored, see Option; errors discarded by those
+// stages do not. When the bucket is empty, the error is dropped: it is neither
+// stored, counted by Len, nor forwarded to piped collectors, and
+// RateLimitDropped reports the number of such errors. The first drop after
+// the bucket ran empty stores a RateLimitedError in this collector, counted
+// by Len, so the loss is visible in the aggregate; it is not stored again
+// until an error was accepted in between:
+//
+//	c := rxmerr.NewCollector(rxmerr.WithRateLimit(100, 10))
+//
+// Time is read from the collector's clock, see WithClock. Unlike MaxRate,
+// which bounds storage per wall-clock second and still counts dropped errors,
+// WithRateLimit smooths bursts and discards dropped errors altogether. A
+// non-positive rate disables the limit; a burst below 1 is treated as 1.
+func WithRateLimit(rate float64, burst int) Option {
+	return func(c *Collector) {
+		if rate <= 0 {
+			c.rateLimit = nil
+			return
+		}
+		burst = max(burst, 1)
+		c.rateLimit = &rateLimitConfig{rate: rate, burst: burst, tokens: float64(burst)}
+	}
+}
+
+// RateLimitDropped returns the number of errors dropped because of
+// WithRateLimit since the collector was created or last Reset.
+func (c *Collector) RateLimitDropped() int {
+	if c.rateLimit == nil {
+		return 0
+	}
+	return c.rateLimit.dropped
+}
+
+// allowRateLimit takes a token from the WithRateLimit bucket. If none is
+// available, it accounts for the dropped error, stores a RateLimitedError if
+// this is the first drop since the bucket ran empty, and returns false.
+func (c *Collector) allowRateLimit() bool {
+	r := c.rateLimit
+	if r == nil {
+		return true
+	}
+
+	now := c.timeFrom(nil)
+	if r.last.IsZero() {
+		r.last = now
+	} else if elapsed := now.Sub(r.last); elapsed > 0 {
+		r.tokens = min(float64(r.burst), r.tokens+elapsed.Seconds()*r.rate)
+		r.last = now
+	}
+	if r.tokens >= 1 {
+		r.tokens--
+		r.notified = false
+		return true
+	}
+
+	r.dropped++
+	if !r.notified {
+		r.notified = true
+		c.store(RateLimitedError{})
+		c.count++
+	}
+	return false
+}
+
+// resetRateLimit refills the WithRateLimit bucket and clears its state,
+// keeping its settings.
+func (c *Collector) resetRateLimit() {
+	if r := c.rateLimit; r != nil {
+		*r = rateLimitConfig{rate: r.rate, burst: r.burst, tokens: float64(r.burst)}
+	}
+}
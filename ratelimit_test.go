@@ -0,0 +1,184 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestMaxRate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.MaxRate(2, fakeClock(&now)))
+
+	steps := []struct {
+		advance         time.Duration
+		appends         int
+		stored, dropped int
+	}{
+		{0, 3, 2, 1},
+		{900 * time.Millisecond, 1, 2, 2},
+		{100 * time.Millisecond, 2, 4, 2},
+		{500 * time.Millisecond, 1, 4, 3},
+		{3 * time.Second, 1, 5, 3},
+	}
+	n := 0
+	for i, s := range steps {
+		now = now.Add(s.advance)
+		for range s.appends {
+			n++
+			c.Append(fmt.Errorf("error %d", n))
+		}
+		if got := c.Stored(); got != s.stored {
+			t.Errorf("step %d: Stored() = %d, want %d", i, got, s.stored)
+		}
+		if got := c.RateDropped(); got != s.dropped {
+			t.Errorf("step %d: RateDropped() = %d, want %d", i, got, s.dropped)
+		}
+		if got := c.Len(); got != n {
+			t.Errorf("step %d: Len() = %d, want %d", i, got, n)
+		}
+	}
+
+	want := []string{"error 1", "error 2", "error 5", "error 6", "error 8", "rxmerr: dropped 3 errors due to rate limit"}
+	if got := rxmerr.Messages(c.Err()); !slices.Equal(got, want) {
+		t.Errorf("Messages(Err()) = %q, want %q", got, want)
+	}
+}
+
+func TestMaxRateReset(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.MaxRate(1, fakeClock(&now)))
+	c.Append(errors.New("a"), errors.New("b"))
+	c.Reset()
+
+	if got := c.RateDropped(); got != 0 {
+		t.Errorf("RateDropped() after Reset = %d, want 0", got)
+	}
+	c.Append(errors.New("c"))
+	if got, want := rxmerr.Messages(c.Err()), []string{"c"}; !slices.Equal(got, want) {
+		t.Errorf("Messages(Err()) after Reset = %q, want %q", got, want)
+	}
+}
+
+func TestMaxRateDisabled(t *testing.T) {
+	c := rxmerr.NewCollector(rxmerr.MaxRate(0, nil))
+	for range 10 {
+		c.Append(errors.New("flood"))
+	}
+	if c.Stored() != 10 || c.RateDropped() != 0 {
+		t.Errorf("Stored() = %d, RateDropped() = %d, want 10 and 0", c.Stored(), c.RateDropped())
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.WithClock(fakeClock(&now)), rxmerr.WithRateLimit(1, 2))
+
+	steps := []struct {
+		advance time.Duration
+		appends int
+		len     int
+		dropped int
+	}{
+		{0, 4, 3, 2},
+		{500 * time.Millisecond, 1, 3, 3},
+		{500 * time.Millisecond, 1, 4, 3},
+		{0, 1, 5, 4},
+	}
+	n := 0
+	for i, s := range steps {
+		now = now.Add(s.advance)
+		for range s.appends {
+			n++
+			c.Append(fmt.Errorf("error %d", n))
+		}
+		if got := c.Len(); got != s.len {
+			t.Errorf("step %d: Len() = %d, want %d", i, got, s.len)
+		}
+		if got := c.RateLimitDropped(); got != s.dropped {
+			t.Errorf("step %d: RateLimitDropped() = %d, want %d", i, got, s.dropped)
+		}
+	}
+
+	want := []string{"error 1", "error 2", "rxmerr: errors dropped due to rate limit", "error 6", "rxmerr: errors dropped due to rate limit"}
+	if got := rxmerr.Messages(c.Err()); !slices.Equal(got, want) {
+		t.Errorf("Messages(Err()) = %q, want %q", got, want)
+	}
+	if !errors.Is(c.Err(), rxmerr.RateLimitedError{}) {
+		t.Errorf("errors.Is(%v, RateLimitedError{}) = false, want true", c.Err())
+	}
+}
+
+func TestWithRateLimitDiscarded(t *testing.T) {
+	errIgnored := errors.New("ignored")
+	errDropped := errors.New("transformed away")
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := rxmerr.NewCollector(
+		rxmerr.WithClock(fakeClock(&now)),
+		rxmerr.WithRateLimit(1, 1),
+		rxmerr.WithIgnore(func(err error) bool { return errors.Is(err, errIgnored) }),
+		rxmerr.WithTransform(func(err error) error {
+			if errors.Is(err, errDropped) {
+				return nil
+			}
+			return err
+		}),
+	)
+
+	for range 10 {
+		c.Append(errIgnored, errDropped)
+	}
+	c.Append(errors.New("a"))
+
+	if got := c.RateLimitDropped(); got != 0 {
+		t.Errorf("RateLimitDropped() = %d, want 0: discarded errors must not consume tokens", got)
+	}
+	if got, want := rxmerr.Messages(c.Err()), []string{"a"}; !slices.Equal(got, want) {
+		t.Errorf("Messages(Err()) = %q, want %q", got, want)
+	}
+}
+
+func TestWithRateLimitReset(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.WithClock(fakeClock(&now)), rxmerr.WithRateLimit(1, 1))
+	c.Append(errors.New("a"), errors.New("b"))
+	c.Reset()
+
+	if got := c.RateLimitDropped(); got != 0 {
+		t.Errorf("RateLimitDropped() after Reset = %d, want 0", got)
+	}
+	c.Append(errors.New("c"))
+	if got, want := rxmerr.Messages(c.Err()), []string{"c"}; !slices.Equal(got, want) {
+		t.Errorf("Messages(Err()) after Reset = %q, want %q", got, want)
+	}
+}
+
+func TestWithRateLimitDisabled(t *testing.T) {
+	c := rxmerr.NewCollector(rxmerr.WithRateLimit(0, 1))
+	for range 10 {
+		c.Append(errors.New("flood"))
+	}
+	if c.Len() != 10 || c.RateLimitDropped() != 0 {
+		t.Errorf("Len() = %d, RateLimitDropped() = %d, want 10 and 0", c.Len(), c.RateLimitDropped())
+	}
+}
@@ -0,0 +1,50 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+// MapResults calls fn for every element of items and returns the results of
+// the successful calls together with the aggregate of the failures.
+//
+// Results are returned in input order; elements for which fn returned a
+// non-nil error are skipped, and their errors are combined in input order.
+// This supports batch operations that process what they can and report the
+// rest:
+//
+//	users, err := rxmerr.MapResults(ids, store.LoadUser)
+//	if err != nil {
+//	    log.Printf("some users could not be loaded: %v", err)
+//	}
+//	render(users)
+//
+// The result value returned alongside a non-nil error is discarded. If every
+// call fails, or items is empty, the returned slice is nil. fn is called
+// sequentially on the calling goroutine.
+func MapResults[T, R any](items []T, fn func(T) (R, error)) ([]R, error) {
+	var (
+		results []R
+		errs    []error
+	)
+	for _, item := range items {
+		r, err := fn(item)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results = append(results, r)
+	}
+	return results, Combine(errs...)
+}
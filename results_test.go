@@ -0,0 +1,69 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestMapResults(t *testing.T) {
+	tests := []struct {
+		name        string
+		items       []string
+		wantResults []int
+		wantMsgs    []string
+	}{
+		{name: "empty"},
+		{name: "all succeed", items: []string{"1", "2"}, wantResults: []int{1, 2}},
+		{
+			name:        "some fail",
+			items:       []string{"1", "x", "3", "y"},
+			wantResults: []int{1, 3},
+			wantMsgs:    []string{"parse x: invalid syntax", "parse y: invalid syntax"},
+		},
+		{
+			name:     "all fail",
+			items:    []string{"x"},
+			wantMsgs: []string{"parse x: invalid syntax"},
+		},
+	}
+
+	atoi := func(s string) (int, error) {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return -1, fmt.Errorf("parse %s: %w", s, strconv.ErrSyntax)
+		}
+		return n, nil
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := rxmerr.MapResults(tt.items, atoi)
+			if !slices.Equal(results, tt.wantResults) {
+				t.Errorf("results = %v, want %v", results, tt.wantResults)
+			}
+			if got := rxmerr.Messages(err); !slices.Equal(got, tt.wantMsgs) {
+				t.Errorf("Messages(err) = %q, want %q", got, tt.wantMsgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetryAfterHint is implemented by errors that carry a hint on how long to
+// wait before retrying the failed operation, such as a parsed HTTP
+// Retry-After header or a gRPC RetryInfo detail.
+type RetryAfterHint interface {
+	RetryAfter() time.Duration
+}
+
+// WithRetryAfter annotates err with a retry-after hint of d.
+//
+// The returned error has the same message as err, unwraps to it, and
+// implements RetryAfterHint. If err is nil, WithRetryAfter returns nil.
+func WithRetryAfter(err error, d time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &retryAfterError{err: err, after: d}
+}
+
+// retryAfterError is the error returned by WithRetryAfter.
+type retryAfterError struct {
+	err   error
+	after time.Duration
+}
+
+func (e *retryAfterError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the annotated error.
+func (e *retryAfterError) Unwrap() error {
+	return e.err
+}
+
+// Format implements fmt.Formatter: %+v formats the annotated error with %+v,
+// %q quotes Error(), and other verbs write Error().
+func (e *retryAfterError) Format(f fmt.State, verb rune) {
+	formatWrapper(f, verb, e, "", e.err)
+}
+
+// RetryAfter implements RetryAfterHint.
+func (e *retryAfterError) RetryAfter() time.Duration {
+	return e.after
+}
+
+// MaxRetryAfter returns the largest retry-after hint found in err.
+//
+// The whole error tree is searched, including nested aggregates and wrapped
+// errors. Within a single chain of wrappers the outermost hint applies, so a
+// hint can be overridden by wrapping the error again with WithRetryAfter.
+// The maximum is the safe choice when the whole batch is retried at once:
+//
+//	if d, ok := rxmerr.MaxRetryAfter(err); ok {
+//	    time.Sleep(d)
+//	}
+//
+// The boolean result reports whether any hint was found; constituents without
+// a hint are ignored. If err is nil, MaxRetryAfter returns (0, false).
+func MaxRetryAfter(err error) (time.Duration, bool) {
+	return foldRetryAfter(err, true)
+}
+
+// MinRetryAfter is like MaxRetryAfter but returns the smallest hint, which
+// suits callers that retry the failed items independently.
+func MinRetryAfter(err error) (time.Duration, bool) {
+	return foldRetryAfter(err, false)
+}
+
+// foldRetryAfter returns the largest or, if largest is false, the smallest
+// retry-after hint found in err.
+func foldRetryAfter(err error, largest bool) (time.Duration, bool) {
+	var (
+		result time.Duration
+		found  bool
+	)
+	walkRetryAfter(err, func(d time.Duration) {
+		if !found || (largest && d > result) || (!largest && d < result) {
+			result, found = d, true
+		}
+	})
+	return result, found
+}
+
+// walkRetryAfter calls fn with the outermost retry-after hint of every branch
+// of the error tree rooted at err.
+func walkRetryAfter(err error, fn func(time.Duration)) {
+	switch e := err.(type) {
+	case nil:
+	case RetryAfterHint:
+		fn(e.RetryAfter())
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			walkRetryAfter(inner, fn)
+		}
+	case interface{ Unwrap() error }:
+		walkRetryAfter(e.Unwrap(), fn)
+	}
+}
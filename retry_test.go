@@ -0,0 +1,77 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestWithRetryAfter(t *testing.T) {
+	if err := rxmerr.WithRetryAfter(nil, time.Second); err != nil {
+		t.Fatalf("WithRetryAfter(nil) = %v, want nil", err)
+	}
+
+	base := errors.New("rate limited")
+	err := rxmerr.WithRetryAfter(base, 2*time.Second)
+	if got, want := err.Error(), base.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, base) {
+		t.Errorf("errors.Is(%v, base) = false", err)
+	}
+	var hint rxmerr.RetryAfterHint
+	if !errors.As(err, &hint) || hint.RetryAfter() != 2*time.Second {
+		t.Errorf("errors.As(RetryAfterHint) = %v, want a hint of 2s", hint)
+	}
+}
+
+func TestMaxMinRetryAfter(t *testing.T) {
+	plain := errors.New("plain")
+	short := rxmerr.WithRetryAfter(errors.New("short"), time.Second)
+	long := rxmerr.WithRetryAfter(errors.New("long"), 5*time.Second)
+
+	tests := []struct {
+		name     string
+		err      error
+		max, min time.Duration
+		ok       bool
+	}{
+		{"nil", nil, 0, 0, false},
+		{"no hints", rxmerr.Combine(plain, plain), 0, 0, false},
+		{"single", short, time.Second, time.Second, true},
+		{"some constituents", rxmerr.Combine(plain, long, short), 5 * time.Second, time.Second, true},
+		{"wrapped", fmt.Errorf("op: %w", rxmerr.Combine(short, fmt.Errorf("call: %w", long))), 5 * time.Second, time.Second, true},
+		{"nested aggregates", rxmerr.Combine(plain, errors.Join(short, rxmerr.Combine(plain, long))), 5 * time.Second, time.Second, true},
+		{"outermost hint", rxmerr.WithRetryAfter(fmt.Errorf("retry: %w", long), 3*time.Second), 3 * time.Second, 3 * time.Second, true},
+		{"zero hint", rxmerr.WithRetryAfter(plain, 0), 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := rxmerr.MaxRetryAfter(tt.err); got != tt.max || ok != tt.ok {
+				t.Errorf("MaxRetryAfter() = (%v, %v), want (%v, %v)", got, ok, tt.max, tt.ok)
+			}
+			if got, ok := rxmerr.MinRetryAfter(tt.err); got != tt.min || ok != tt.ok {
+				t.Errorf("MinRetryAfter() = (%v, %v), want (%v, %v)", got, ok, tt.min, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrRollbackFailed is matched by errors.Is in the result of RunWithRollback
+// if and only if at least one undo function failed.
+var ErrRollbackFailed = errors.New("rxmerr: rollback failed")
+
+// Step is a named, undoable step of a saga-style operation run by
+// RunWithRollback.
+type Step struct {
+	// Name identifies the step in error messages.
+	Name string
+
+	// Do performs the step. A nil Do always succeeds.
+	Do func() error
+
+	// Undo reverts a completed step. A nil Undo means the step needs no
+	// rollback.
+	Undo func() error
+}
+
+// RunWithRollback runs the Do functions of steps in order and stops at the
+// first failure. It then runs the Undo functions of the steps that completed,
+// in reverse order, and returns an aggregate reporting the failure and the
+// outcome of the rollback:
+//
+//	err := rxmerr.RunWithRollback([]rxmerr.Step{
+//	    {Name: "reserve stock", Do: reserve, Undo: release},
+//	    {Name: "charge card", Do: charge, Undo: refund},
+//	    {Name: "ship", Do: ship},
+//	})
+//	if errors.Is(err, rxmerr.ErrRollbackFailed) {
+//	    // the system is in an inconsistent state
+//	}
+//
+// The first constituent of the aggregate is the error of the failed step,
+// wrapped as "<name>: <err>". It is followed, in the order the undo
+// functions ran, by one constituent per failed undo, wrapped as
+// "rollback of <name> failed: <err>"; these match ErrRollbackFailed. A
+// rollback continues past failed undo functions, so that every completed step
+// gets a chance to be reverted.
+//
+// A panic in a Do or Undo function is recovered and reported like a returned
+// error, see DoWithRecover. If all steps succeed, RunWithRollback returns nil
+// and no Undo function is called.
+func RunWithRollback(steps []Step) error {
+	for i, step := range steps {
+		err := callRecovering(step.Do)
+		if err == nil {
+			continue
+		}
+
+		c := NewCollector()
+		c.Append(&annotatedError{msg: step.Name, err: err})
+		for j := i - 1; j >= 0; j-- {
+			if undoErr := callRecovering(steps[j].Undo); undoErr != nil {
+				c.Append(&rollbackError{name: steps[j].Name, err: undoErr})
+			}
+		}
+		return c.Err()
+	}
+	return nil
+}
+
+// callRecovering calls fn, if it is not nil, and returns its error or the
+// recovered panic converted by FromPanic.
+func callRecovering(fn func() error) (err error) {
+	if fn == nil {
+		return nil
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			err = FromPanic(v)
+		}
+	}()
+	return fn()
+}
+
+// rollbackError reports a failed undo function of RunWithRollback.
+type rollbackError struct {
+	name string
+	err  error
+}
+
+func (e *rollbackError) Error() string {
+	return fmt.Sprintf("rollback of %s failed: %s", e.name, e.err.Error())
+}
+
+// Unwrap returns the error of the undo function.
+func (e *rollbackError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is ErrRollbackFailed.
+func (e *rollbackError) Is(target error) bool {
+	return target == ErrRollbackFailed
+}
+
+// Format implements fmt.Formatter: %+v writes the message prefix followed by
+// the %+v output of the undo error, %q quotes Error(), and other verbs write
+// Error().
+func (e *rollbackError) Format(f fmt.State, verb rune) {
+	formatWrapper(f, verb, e, "rollback of "+e.name+" failed: ", e.err)
+}
@@ -0,0 +1,145 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestRunWithRollback(t *testing.T) {
+	errDo := errors.New("card declined")
+	errUndo := errors.New("release failed")
+	succeed := func() error { return nil }
+	fail := func(err error) func() error { return func() error { return err } }
+
+	tests := []struct {
+		name         string
+		steps        func(log *[]string) []rxmerr.Step
+		wantMsgs     []string
+		wantRollback bool
+		wantLog      []string
+	}{
+		{
+			name: "all succeed",
+			steps: func(log *[]string) []rxmerr.Step {
+				return []rxmerr.Step{
+					{Name: "a", Do: succeed, Undo: logged(log, "undo a", nil)},
+					{Name: "b", Do: succeed, Undo: logged(log, "undo b", nil)},
+				}
+			},
+		},
+		{
+			name: "undo in reverse order",
+			steps: func(log *[]string) []rxmerr.Step {
+				return []rxmerr.Step{
+					{Name: "reserve", Do: succeed, Undo: logged(log, "undo reserve", nil)},
+					{Name: "bill", Do: succeed},
+					{Name: "charge", Do: succeed, Undo: logged(log, "undo charge", nil)},
+					{Name: "ship", Do: fail(errDo), Undo: logged(log, "undo ship", nil)},
+					{Name: "notify", Do: logged(log, "notify", nil)},
+				}
+			},
+			wantMsgs: []string{"ship: card declined"},
+			wantLog:  []string{"undo charge", "undo reserve"},
+		},
+		{
+			name: "failed undo continues",
+			steps: func(log *[]string) []rxmerr.Step {
+				return []rxmerr.Step{
+					{Name: "reserve", Do: succeed, Undo: logged(log, "undo reserve", nil)},
+					{Name: "stock", Do: succeed, Undo: logged(log, "undo stock", errUndo)},
+					{Name: "charge", Do: fail(errDo)},
+				}
+			},
+			wantMsgs:     []string{"charge: card declined", "rollback of stock failed: release failed"},
+			wantRollback: true,
+			wantLog:      []string{"undo stock", "undo reserve"},
+		},
+		{
+			name: "first step fails",
+			steps: func(log *[]string) []rxmerr.Step {
+				return []rxmerr.Step{
+					{Name: "connect", Do: fail(io.EOF), Undo: logged(log, "undo connect", nil)},
+				}
+			},
+			wantMsgs: []string{"connect: EOF"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var log []string
+			err := rxmerr.RunWithRollback(tt.steps(&log))
+
+			if got := rxmerr.Messages(err); !slices.Equal(got, tt.wantMsgs) {
+				t.Errorf("Messages(RunWithRollback()) = %q, want %q", got, tt.wantMsgs)
+			}
+			if got := errors.Is(err, rxmerr.ErrRollbackFailed); got != tt.wantRollback {
+				t.Errorf("errors.Is(%v, ErrRollbackFailed) = %v, want %v", err, got, tt.wantRollback)
+			}
+			if !slices.Equal(log, tt.wantLog) {
+				t.Errorf("calls = %q, want %q", log, tt.wantLog)
+			}
+		})
+	}
+}
+
+func TestRunWithRollbackUnwrap(t *testing.T) {
+	errDo := errors.New("card declined")
+	err := rxmerr.RunWithRollback([]rxmerr.Step{
+		{Name: "reserve", Do: func() error { return nil }, Undo: func() error { return io.ErrClosedPipe }},
+		{Name: "charge", Do: func() error { return errDo }},
+	})
+
+	if !errors.Is(err, errDo) || !errors.Is(err, io.ErrClosedPipe) {
+		t.Errorf("RunWithRollback() = %v, step and undo errors not reachable via errors.Is", err)
+	}
+	if errs := rxmerr.Errors(err); len(errs) != 2 || errors.Is(errs[0], rxmerr.ErrRollbackFailed) {
+		t.Errorf("Errors(RunWithRollback()) = %v, want the step error first, not matching ErrRollbackFailed", errs)
+	}
+}
+
+func TestRunWithRollbackPanic(t *testing.T) {
+	err := rxmerr.RunWithRollback([]rxmerr.Step{
+		{Name: "reserve", Do: func() error { return nil }, Undo: func() error { panic("undo boom") }},
+		{Name: "charge", Do: func() error { panic("do boom") }},
+	})
+
+	want := []string{"charge: panic: do boom", "rollback of reserve failed: panic: undo boom"}
+	if got := rxmerr.Messages(err); !slices.Equal(got, want) {
+		t.Errorf("Messages(RunWithRollback()) = %q, want %q", got, want)
+	}
+	if !rxmerr.IsPanic(err) {
+		t.Errorf("IsPanic(%v) = false, want true", err)
+	}
+	if !errors.Is(err, rxmerr.ErrRollbackFailed) {
+		t.Errorf("errors.Is(%v, ErrRollbackFailed) = false, want true for a panicking undo", err)
+	}
+}
+
+// logged returns a step function that records name in log and returns err.
+func logged(log *[]string, name string, err error) func() error {
+	return func() error {
+		*log = append(*log, name)
+		return err
+	}
+}
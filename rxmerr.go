@@ -56,12 +56,21 @@ func Append(left, right error) error {
 // exactly one element: err itself. If err is a multi-error, Errors returns
 // the full set of its constituent errors.
 //
-// The returned slice MUST be treated as read-only by callers. Its exact
-// ordering and other details are defined by go.uber.org/multierr.
+// A non-nil err always yields at least one element: an aggregate without any
+// constituents, such as fmt.Errorf("%w %w", nil, nil), is returned as the
+// single element instead of being reported as no errors at all.
+//
+// The returned slice is a fresh copy owned by the caller; modifying it does
+// not affect err. Its exact ordering and other details are defined by
+// go.uber.org/multierr.
 //
 // This is a thin convenience wrapper around multierr.Errors.
 func Errors(err error) []error {
-	return multierr.Errors(err)
+	errs := multierr.Errors(err)
+	if len(errs) == 0 && err != nil {
+		return []error{err}
+	}
+	return errs
 }
 
 // AppendInto appends an error into the destination pointed to by dst.
@@ -80,8 +89,12 @@ func Errors(err error) []error {
 //
 // Nil err values are ignored in the same way as by Append.
 //
-// This is a thin convenience wrapper around multierr.AppendInto.
+// This is a thin convenience wrapper around multierr.AppendInto. Non-nil
+// errors are also reported to the hook installed by SetDebugHook, if any.
 func AppendInto(dst *error, err error) {
+	if err != nil {
+		callDebugHook(err)
+	}
 	multierr.AppendInto(dst, err)
 }
 
@@ -103,7 +116,8 @@ func AppendInto(dst *error, err error) {
 // appended using AppendInto. This function does not recover from panics in fn;
 // any panic propagates to the caller.
 //
-// This is a thin convenience wrapper around multierr.AppendFunc.
+// This is equivalent to multierr.AppendFunc, except that it goes through
+// AppendInto so that the hook installed by SetDebugHook observes the error.
 func AppendFunc(dst *error, fn func() error) {
-	multierr.AppendFunc(dst, fn)
+	AppendInto(dst, fn())
 }
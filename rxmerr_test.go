@@ -0,0 +1,225 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestCombine(t *testing.T) {
+	errA, errB, errC := errors.New("a"), errors.New("b"), errors.New("c")
+
+	tests := []struct {
+		name string
+		errs []error
+		want []error
+	}{
+		{"no errors", nil, nil},
+		{"all nil", []error{nil, nil}, nil},
+		{"single", []error{nil, errA, nil}, []error{errA}},
+		{"ordered", []error{errA, errB, errC}, []error{errA, errB, errC}},
+		{"nested", []error{rxmerr.Combine(errA, errB), errC}, []error{errA, errB, errC}},
+		{"nested last", []error{errA, rxmerr.Combine(errB, errC)}, []error{errA, errB, errC}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rxmerr.Combine(tt.errs...)
+			if got := rxmerr.Errors(err); !slices.Equal(got, tt.want) {
+				t.Errorf("Errors(Combine()) = %v, want %v", got, tt.want)
+			}
+			if len(tt.want) == 1 && err != tt.want[0] {
+				t.Errorf("Combine() = %#v, want the single error as-is", err)
+			}
+		})
+	}
+}
+
+func TestAppend(t *testing.T) {
+	errA, errB, errC := errors.New("a"), errors.New("b"), errors.New("c")
+
+	tests := []struct {
+		name        string
+		left, right error
+		want        []error
+	}{
+		{"both nil", nil, nil, nil},
+		{"left only", errA, nil, []error{errA}},
+		{"right only", nil, errB, []error{errB}},
+		{"both", errA, errB, []error{errA, errB}},
+		{"left aggregate", rxmerr.Combine(errA, errB), errC, []error{errA, errB, errC}},
+		{"right aggregate", errA, rxmerr.Combine(errB, errC), []error{errA, errB, errC}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rxmerr.Append(tt.left, tt.right)
+			if got := rxmerr.Errors(err); !slices.Equal(got, tt.want) {
+				t.Errorf("Errors(Append()) = %v, want %v", got, tt.want)
+			}
+			if len(tt.want) == 1 && err != tt.want[0] {
+				t.Errorf("Append() = %#v, want the single error as-is", err)
+			}
+		})
+	}
+}
+
+func TestAppendInto(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+
+	var err error
+	rxmerr.AppendInto(&err, nil)
+	if err != nil {
+		t.Fatalf("AppendInto(nil) = %v, want nil", err)
+	}
+	rxmerr.AppendInto(&err, errA)
+	if err != errA {
+		t.Fatalf("AppendInto(a) = %#v, want a as-is", err)
+	}
+	rxmerr.AppendInto(&err, nil)
+	rxmerr.AppendInto(&err, errB)
+	if got, want := rxmerr.Errors(err), []error{errA, errB}; !slices.Equal(got, want) {
+		t.Errorf("Errors() = %v, want %v", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("AppendInto(nil, err) did not panic")
+		}
+	}()
+	rxmerr.AppendInto(nil, errA)
+}
+
+func TestErrors(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	empty := fmt.Errorf("%w %w", nil, nil)
+
+	tests := []struct {
+		name string
+		err  error
+		want []error
+	}{
+		{"nil", nil, nil},
+		{"leaf", errA, []error{errA}},
+		{"wrapped", fmt.Errorf("op: %w", errA), nil},
+		{"empty aggregate", empty, []error{empty}},
+		{"aggregate", rxmerr.Combine(errA, errB), []error{errA, errB}},
+		{"join", errors.Join(errA, errB), []error{errA, errB}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rxmerr.Errors(tt.err)
+			want := tt.want
+			if want == nil && tt.err != nil {
+				want = []error{tt.err}
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("Errors() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestErrorsCopy(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	err := rxmerr.Combine(errA, errB)
+
+	errs := rxmerr.Errors(err)
+	errs[0] = nil
+	if got, want := rxmerr.Errors(err), []error{errA, errB}; !slices.Equal(got, want) {
+		t.Errorf("Errors() after modifying a previous result = %v, want %v", got, want)
+	}
+}
+
+func TestCollectorContract(t *testing.T) {
+	errA, errB, errC := errors.New("a"), errors.New("b"), errors.New("c")
+
+	tests := []struct {
+		name string
+		errs []error
+	}{
+		{"none", nil},
+		{"nil only", []error{nil, nil}},
+		{"single", []error{errA}},
+		{"several", []error{errA, nil, errB, errC}},
+		{"combined", []error{rxmerr.Combine(errA, errB)}},
+		{"combined and leaf", []error{rxmerr.Combine(errA, errB), errC}},
+		{"joined", []error{errors.Join(errA, errB)}},
+		{"joined and leaf", []error{errors.Join(errA, errB), errC}},
+		{"empty aggregate", []error{fmt.Errorf("%w %w", nil, nil)}},
+	}
+	backends := []struct {
+		name    string
+		opts    []rxmerr.Option
+		combine func(...error) error
+	}{
+		{"multierr", nil, rxmerr.Combine},
+		{"join", []rxmerr.Option{rxmerr.WithJoinBackend()}, rxmerr.JoinCombine},
+	}
+	for _, b := range backends {
+		for _, tt := range tests {
+			t.Run(b.name+"/"+tt.name, func(t *testing.T) {
+				c := rxmerr.NewCollector(b.opts...)
+				c.Append(tt.errs...)
+
+				errs := c.Errors()
+				if len(errs) != c.Len() {
+					t.Errorf("len(Errors()) = %d, Len() = %d", len(errs), c.Len())
+				}
+				err := c.Err()
+				if (err == nil) != (c.Len() == 0) {
+					t.Errorf("Err() = %v with Len() = %d", err, c.Len())
+				}
+				if err == nil {
+					if errs != nil {
+						t.Errorf("Errors() = %v, want nil", errs)
+					}
+					return
+				}
+				if got, want := b.combine(errs...).Error(), err.Error(); got != want {
+					t.Errorf("Combine(Errors()...) = %q, Err() = %q", got, want)
+				}
+				for _, e := range errs {
+					for _, leaf := range rxmerr.Errors(e) {
+						if !errors.Is(err, leaf) {
+							t.Errorf("errors.Is(Err(), %v) = false", leaf)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestCollectorErrorsCopy(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	c := rxmerr.NewCollector()
+	c.Append(errA, errB)
+	err := c.Err()
+
+	errs := c.Errors()
+	errs[0] = nil
+	if got, want := c.Errors(), []error{errA, errB}; !slices.Equal(got, want) {
+		t.Errorf("Errors() after modifying a previous result = %v, want %v", got, want)
+	}
+	if got, want := err.Error(), "a; b"; got != want {
+		t.Errorf("Err() after modifying Errors() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package rxmerrtest provides test assertions for code that aggregates errors
+// with package rxmerr.
+//
+// The helpers accept testing.TB, so they work with *testing.T, *testing.B and
+// *testing.F alike. Keeping them in a separate package means the main rxmerr
+// package never imports package testing.
+package rxmerrtest
+
+import (
+	"io"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+// RequireNoError fails the test immediately if c has collected any errors.
+//
+// On failure, RequireNoError calls t.Fatalf with the full Collector.Report
+// output, so every collected error is visible in the test log rather than
+// only the first one. If c has no errors, RequireNoError does nothing.
+//
+//	c := rxmerr.NewCollector()
+//	for _, item := range batch {
+//	    c.Append(process(item))
+//	}
+//	rxmerrtest.RequireNoError(t, c)
+func RequireNoError(t testing.TB, c *rxmerr.Collector) {
+	t.Helper()
+	if c.HasError() {
+		t.Fatalf("unexpected errors collected:\n%s", c.Report())
+	}
+}
+
+// Cleanup returns a new collector that is checked when the test ends.
+//
+// Cleanup registers a function with t.Cleanup that calls t.Errorf with the
+// Collector.Report rendering (see rxmerr.Format) if any errors were collected
+// by then. This makes errors that would otherwise be dropped, such as those of
+// deferred Close calls, fail the test without further boilerplate:
+//
+//	c := rxmerrtest.Cleanup(t)
+//	srv := startServer(t)
+//	defer c.AppendFunc(srv.Close)
+//
+// Because t.Errorf rather than t.Fatalf is used, other cleanup functions
+// still run. Cleanup functions run in last-added, first-called order, so
+// errors appended by cleanups registered after Cleanup was called are still
+// reported.
+func Cleanup(t testing.TB) *rxmerr.Collector {
+	t.Helper()
+	c := rxmerr.NewCollector()
+	t.Cleanup(func() {
+		if c.HasError() {
+			t.Errorf("errors collected during test:\n%s", c.Report())
+		}
+	})
+	return c
+}
+
+// CloseOnCleanup registers closer to be closed when the test ends, and fails
+// the test with t.Errorf if Close returns an error. The error is rendered in
+// the same way as by Cleanup:
+//
+//	f, err := os.Open(path)
+//	...
+//	rxmerrtest.CloseOnCleanup(t, f)
+func CloseOnCleanup(t testing.TB, closer io.Closer) {
+	t.Helper()
+	t.Cleanup(func() {
+		if err := closer.Close(); err != nil {
+			t.Errorf("close failed:\n%s", rxmerr.Format(err))
+		}
+	})
+}
@@ -0,0 +1,168 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerrtest_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+	"dirpx.dev/rxmerr/rxmerrtest"
+)
+
+// fakeTB records failures instead of failing the enclosing test. Methods not
+// overridden panic through the nil embedded testing.TB.
+type fakeTB struct {
+	testing.TB
+	fatals   []string
+	errors   []string
+	cleanups []func()
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Fatalf(format string, args ...any) {
+	tb.fatals = append(tb.fatals, fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) Cleanup(fn func()) {
+	tb.cleanups = append(tb.cleanups, fn)
+}
+
+// finish runs the registered cleanup functions in last-added, first-called
+// order, as the testing package does at the end of a test.
+func (tb *fakeTB) finish() {
+	for i := len(tb.cleanups) - 1; i >= 0; i-- {
+		tb.cleanups[i]()
+	}
+}
+
+func TestRequireNoError(t *testing.T) {
+	tests := []struct {
+		name      string
+		errs      []error
+		wantFatal bool
+	}{
+		{name: "empty"},
+		{name: "only nil", errs: []error{nil, nil}},
+		{name: "one error", errs: []error{errors.New("a")}, wantFatal: true},
+		{name: "two errors", errs: []error{errors.New("a"), nil, errors.New("b")}, wantFatal: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := rxmerr.NewCollector()
+			c.Append(tt.errs...)
+
+			tb := &fakeTB{}
+			rxmerrtest.RequireNoError(tb, c)
+
+			if got := len(tb.fatals) > 0; got != tt.wantFatal {
+				t.Fatalf("Fatalf called = %v, want %v", got, tt.wantFatal)
+			}
+			if len(tb.fatals) > 1 || len(tb.errors) > 0 {
+				t.Errorf("fatals = %q, errors = %q, want at most one fatal", tb.fatals, tb.errors)
+			}
+			if tt.wantFatal && !strings.Contains(tb.fatals[0], c.Report()) {
+				t.Errorf("Fatalf message = %q, want it to contain %q", tb.fatals[0], c.Report())
+			}
+		})
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	t.Run("no errors", func(t *testing.T) {
+		tb := &fakeTB{}
+		c := rxmerrtest.Cleanup(tb)
+		c.Append(nil)
+		tb.finish()
+		if len(tb.errors) > 0 || len(tb.fatals) > 0 {
+			t.Errorf("errors = %q, fatals = %q, want none", tb.errors, tb.fatals)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tb := &fakeTB{}
+		c := rxmerrtest.Cleanup(tb)
+		c.Append(errors.New("a"), errors.New("b"))
+		if len(tb.errors) > 0 {
+			t.Fatalf("Errorf called before the test ended: %q", tb.errors)
+		}
+		tb.finish()
+		if len(tb.errors) != 1 || len(tb.fatals) > 0 {
+			t.Fatalf("errors = %q, fatals = %q, want one error", tb.errors, tb.fatals)
+		}
+		if !strings.Contains(tb.errors[0], c.Report()) {
+			t.Errorf("Errorf message = %q, want it to contain %q", tb.errors[0], c.Report())
+		}
+	})
+
+	t.Run("later cleanups", func(t *testing.T) {
+		tb := &fakeTB{}
+		c := rxmerrtest.Cleanup(tb)
+		tb.Cleanup(func() { c.Append(errors.New("close: broken pipe")) })
+		tb.finish()
+		if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "close: broken pipe") {
+			t.Errorf("errors = %q, want the error appended by a later cleanup", tb.errors)
+		}
+	})
+}
+
+// closerFunc adapts a function to io.Closer.
+type closerFunc func() error
+
+func (f closerFunc) Close() error { return f() }
+
+func TestCloseOnCleanup(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{name: "success"},
+		{name: "failure", err: errors.New("close: broken pipe"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &fakeTB{}
+			closed := false
+			rxmerrtest.CloseOnCleanup(tb, closerFunc(func() error {
+				closed = true
+				return tt.err
+			}))
+			if closed {
+				t.Fatal("closer closed before the test ended")
+			}
+			tb.finish()
+			if !closed {
+				t.Fatal("closer not closed when the test ended")
+			}
+			if got := len(tb.errors) > 0; got != tt.wantErr {
+				t.Fatalf("Errorf called = %v, want %v", got, tt.wantErr)
+			}
+			if tt.wantErr && !strings.Contains(tb.errors[0], rxmerr.Format(tt.err)) {
+				t.Errorf("Errorf message = %q, want it to contain %q", tb.errors[0], rxmerr.Format(tt.err))
+			}
+		})
+	}
+}
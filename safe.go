@@ -0,0 +1,175 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/multierr"
+)
+
+// SafeCollector accumulates non-nil errors from multiple goroutines.
+//
+// SafeCollector offers the core subset of the Collector API, with every
+// method safe for concurrent use. It does not support Collector options.
+//
+//	sc := rxmerr.NewSafeCollector()
+//	var wg sync.WaitGroup
+//	for _, shard := range shards {
+//	    wg.Go(func() { sc.AppendFrom(shard.Name, shard.Sync()) })
+//	}
+//	wg.Wait()
+//	return sc.Err()
+//
+// Errors appended concurrently are stored in the order in which the
+// appending goroutines acquire the collector's lock. The zero value is an
+// empty SafeCollector ready for use. A SafeCollector MUST NOT be copied after
+// first use.
+//
+// # Reads
+//
+// Reading methods (Snapshot, Err, Errors, Len, HasError) never take the lock
+// that appending goroutines contend on. Collected errors are kept in an
+// append-only slice: elements are never modified once written, and after
+// every append the collector atomically publishes a slice header covering
+// the elements written so far. A reader loads the latest header and sees a
+// consistent prefix of the collection in O(1), no matter how many errors have
+// been collected or how many writers are active. Growing the slice copies it
+// to a new array and leaves the old one, which earlier readers may still
+// hold, untouched; Reset starts a new slice in the same way.
+type SafeCollector struct {
+	mu      sync.Mutex              // serializes writers
+	errs    []error                 // collected errors, in collection order; append-only
+	view    atomic.Pointer[[]error] // published prefix of errs, read without mu
+	sources map[string]struct{}     // distinct sources passed to AppendFrom
+}
+
+// NewSafeCollector creates a new, empty SafeCollector.
+func NewSafeCollector() *SafeCollector {
+	return &SafeCollector{}
+}
+
+// Append adds a non-nil error to the collector. Nil errors are ignored.
+func (sc *SafeCollector) Append(err error) {
+	if err == nil {
+		return
+	}
+	callDebugHook(err)
+
+	sc.mu.Lock()
+	sc.appendLocked(err)
+	sc.mu.Unlock()
+}
+
+// appendLocked appends err and publishes the new view. sc.mu MUST be held.
+func (sc *SafeCollector) appendLocked(err error) {
+	sc.errs = append(sc.errs, err)
+	view := slices.Clip(sc.errs)
+	sc.view.Store(&view)
+}
+
+// AppendFrom adds a non-nil error reported by the named source, such as a
+// worker or shard.
+//
+// The error is stored as a *SourceError, so its message identifies the
+// source while the original error remains reachable via errors.Is and
+// errors.As. The source is recorded for SourcesWithErrors. Nil errors are
+// ignored and do not record the source.
+func (sc *SafeCollector) AppendFrom(source string, err error) {
+	if err == nil {
+		return
+	}
+	callDebugHook(err)
+
+	tagged := &SourceError{Source: source, Err: err}
+	sc.mu.Lock()
+	sc.appendLocked(tagged)
+	if sc.sources == nil {
+		sc.sources = make(map[string]struct{})
+	}
+	sc.sources[source] = struct{}{}
+	sc.mu.Unlock()
+}
+
+// SourcesWithErrors returns the distinct sources that reported at least one
+// error through AppendFrom, sorted lexically. It returns nil if no source has
+// reported an error.
+func (sc *SafeCollector) SourcesWithErrors() []string {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if len(sc.sources) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(sc.sources))
+	for source := range sc.sources {
+		out = append(out, source)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// Snapshot returns the errors collected so far, in collection order, or nil
+// if none were collected.
+//
+// Snapshot does not copy: it returns the view published by the latest
+// append in O(1) and without blocking writers, which makes it suitable for
+// frequent polling, for example by a health endpoint. The returned slice is
+// shared with the collector and other readers and MUST NOT be modified;
+// appending to it is safe, as its capacity equals its length. Errors returns
+// a private copy instead.
+func (sc *SafeCollector) Snapshot() []error {
+	if view := sc.view.Load(); view != nil && len(*view) > 0 {
+		return *view
+	}
+	return nil
+}
+
+// Err returns the aggregate of all collected errors, or nil if none were
+// collected. See Collector.Err.
+//
+// Err reads the current Snapshot and does not block writers.
+func (sc *SafeCollector) Err() error {
+	return multierr.Combine(sc.Snapshot()...)
+}
+
+// Errors returns all collected errors as a new slice, in collection order, or
+// nil if none were collected. See Collector.Errors.
+func (sc *SafeCollector) Errors() []error {
+	return slices.Clone(sc.Snapshot())
+}
+
+// Len returns the number of collected errors.
+func (sc *SafeCollector) Len() int {
+	return len(sc.Snapshot())
+}
+
+// HasError reports whether at least one error has been collected.
+func (sc *SafeCollector) HasError() bool {
+	return sc.Len() > 0
+}
+
+// Reset clears all collected errors and recorded sources.
+func (sc *SafeCollector) Reset() {
+	sc.mu.Lock()
+	sc.errs = nil
+	sc.view.Store(nil)
+	sc.sources = nil
+	sc.mu.Unlock()
+}
@@ -0,0 +1,252 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestSafeCollectorAppendFrom(t *testing.T) {
+	const perWorker = 50
+	workers := []string{"shard-c", "shard-a", "shard-b", "shard-d"}
+	errSync := errors.New("sync failed")
+
+	sc := rxmerr.NewSafeCollector()
+	var wg sync.WaitGroup
+	for _, name := range workers {
+		wg.Go(func() {
+			for i := range perWorker {
+				if name == "shard-d" {
+					sc.AppendFrom(name, nil) // succeeds every time
+					continue
+				}
+				sc.AppendFrom(name, fmt.Errorf("item %d: %w", i, errSync))
+			}
+		})
+	}
+	wg.Wait()
+
+	want := []string{"shard-a", "shard-b", "shard-c"}
+	if got := sc.SourcesWithErrors(); !slices.Equal(got, want) {
+		t.Errorf("SourcesWithErrors() = %q, want %q", got, want)
+	}
+	if got := sc.Len(); got != 3*perWorker {
+		t.Errorf("Len() = %d, want %d", got, 3*perWorker)
+	}
+
+	perSource := make(map[string]int)
+	for _, err := range sc.Errors() {
+		var serr *rxmerr.SourceError
+		if !errors.As(err, &serr) {
+			t.Fatalf("collected error %v is not a *SourceError", err)
+		}
+		if !errors.Is(err, errSync) {
+			t.Errorf("collected error %v does not unwrap to the original", err)
+		}
+		perSource[serr.Source]++
+	}
+	for _, name := range want {
+		if perSource[name] != perWorker {
+			t.Errorf("%s reported %d errors, want %d", name, perSource[name], perWorker)
+		}
+	}
+}
+
+func TestSafeCollectorSourcesReset(t *testing.T) {
+	sc := rxmerr.NewSafeCollector()
+	if got := sc.SourcesWithErrors(); got != nil {
+		t.Errorf("SourcesWithErrors() on empty collector = %q, want nil", got)
+	}
+
+	sc.AppendFrom("a", errors.New("x"))
+	sc.Reset()
+	if got := sc.SourcesWithErrors(); got != nil {
+		t.Errorf("SourcesWithErrors() after Reset = %q, want nil", got)
+	}
+}
+
+// seqError identifies the n-th error appended by a writer.
+type seqError struct {
+	writer, n int
+}
+
+func (e *seqError) Error() string {
+	return fmt.Sprintf("writer %d: error %d", e.writer, e.n)
+}
+
+// checkSnapshot reports an error if snap is not a valid view: every element
+// must be a *seqError, and the errors of each writer must appear in the
+// order in which they were appended, without gaps.
+func checkSnapshot(snap []error) error {
+	next := map[int]int{}
+	for i, err := range snap {
+		se, ok := err.(*seqError)
+		if !ok {
+			return fmt.Errorf("element %d = %#v, want a *seqError", i, err)
+		}
+		if se.n != next[se.writer] {
+			return fmt.Errorf("element %d = %v, want error %d of writer %d", i, se, next[se.writer], se.writer)
+		}
+		next[se.writer]++
+	}
+	return nil
+}
+
+func TestSafeCollectorConcurrentSnapshots(t *testing.T) {
+	const (
+		writers   = 4
+		perWriter = 2000
+		readers   = 4
+	)
+	sc := rxmerr.NewSafeCollector()
+
+	var writersWG, readersWG sync.WaitGroup
+	done := make(chan struct{})
+	failures := make(chan error, readers)
+	for range readers {
+		readersWG.Go(func() {
+			var prev []error
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				snap := sc.Snapshot()
+				if err := checkSnapshot(snap); err != nil {
+					failures <- err
+					return
+				}
+				if len(snap) < len(prev) || !slices.Equal(snap[:len(prev)], prev) {
+					failures <- fmt.Errorf("snapshot of %d errors does not extend the previous one of %d", len(snap), len(prev))
+					return
+				}
+				if n := sc.Len(); n < len(snap) {
+					failures <- fmt.Errorf("Len() = %d after a snapshot of %d errors", n, len(snap))
+					return
+				}
+				prev = snap
+			}
+		})
+	}
+	for w := range writers {
+		writersWG.Go(func() {
+			for n := range perWriter {
+				sc.Append(&seqError{writer: w, n: n})
+			}
+		})
+	}
+	writersWG.Wait()
+	close(done)
+	readersWG.Wait()
+	close(failures)
+
+	for err := range failures {
+		t.Error(err)
+	}
+	snap := sc.Snapshot()
+	if err := checkSnapshot(snap); err != nil {
+		t.Error(err)
+	}
+	if got := len(snap); got != writers*perWriter {
+		t.Errorf("len(Snapshot()) = %d, want %d", got, writers*perWriter)
+	}
+	if got := len(rxmerr.Errors(sc.Err())); got != writers*perWriter {
+		t.Errorf("len(Errors(Err())) = %d, want %d", got, writers*perWriter)
+	}
+}
+
+func TestSafeCollectorConcurrentReset(t *testing.T) {
+	const perWriter = 1000
+	sc := rxmerr.NewSafeCollector()
+
+	var wg sync.WaitGroup
+	for w := range 2 {
+		wg.Go(func() {
+			for n := range perWriter {
+				sc.Append(&seqError{writer: w, n: n})
+			}
+		})
+	}
+	wg.Go(func() {
+		for range 100 {
+			sc.Reset()
+		}
+	})
+	wg.Go(func() {
+		for range 1000 {
+			// Sequences are cut by Reset, so only the element types are
+			// checked.
+			for i, err := range sc.Snapshot() {
+				if _, ok := err.(*seqError); !ok {
+					t.Errorf("Snapshot()[%d] = %#v, want a *seqError", i, err)
+					return
+				}
+			}
+			_ = sc.Err()
+			_ = sc.Errors()
+		}
+	})
+	wg.Wait()
+}
+
+// BenchmarkSafeCollectorSnapshot measures the latency of Snapshot while
+// writers append in parallel, for collections of increasing size. The time
+// per operation is expected not to grow with the size.
+func BenchmarkSafeCollectorSnapshot(b *testing.B) {
+	for _, size := range []int{100, 10_000, 1_000_000} {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			sc := rxmerr.NewSafeCollector()
+			errFail := errors.New("fail")
+			for range size {
+				sc.Append(errFail)
+			}
+
+			stop := make(chan struct{})
+			var wg sync.WaitGroup
+			for range 4 {
+				wg.Go(func() {
+					// Bound the appends to keep memory in check on long runs.
+					for range 1 << 20 {
+						select {
+						case <-stop:
+							return
+						default:
+							sc.Append(errFail)
+						}
+					}
+				})
+			}
+
+			b.ReportAllocs()
+			for b.Loop() {
+				if len(sc.Snapshot()) < size {
+					b.Fatal("Snapshot() lost errors")
+				}
+			}
+			b.StopTimer()
+			close(stop)
+			wg.Wait()
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import "errors"
+
+// ScorePair associates a target error with the number of points a matching
+// constituent contributes. See ScoreByIs.
+type ScorePair struct {
+	// Target is matched against constituents with errors.Is.
+	Target error
+
+	// Points is the score of a constituent that matches Target.
+	Points int
+}
+
+// Score returns the sum of scorer over all constituents of err.
+//
+// Constituents are obtained via Errors. Score answers "how bad is this
+// aggregate as a whole", which is useful for routing decisions such as
+// paging versus filing a ticket:
+//
+//	scorer := rxmerr.ScoreByIs(
+//	    rxmerr.ScorePair{Target: ErrDataLoss, Points: 100},
+//	    rxmerr.ScorePair{Target: ErrTimeout, Points: 5},
+//	)
+//	if rxmerr.Score(err, scorer) >= pageThreshold {
+//	    page(err)
+//	}
+//
+// If err is nil, Score returns 0.
+func Score(err error, scorer func(error) int) int {
+	total := 0
+	for _, e := range Errors(err) {
+		total += scorer(e)
+	}
+	return total
+}
+
+// MaxScore returns the highest value of scorer over all constituents of err.
+//
+// Constituents are obtained via Errors. If err is nil, MaxScore returns 0.
+func MaxScore(err error, scorer func(error) int) int {
+	errs := Errors(err)
+	if len(errs) == 0 {
+		return 0
+	}
+
+	best := scorer(errs[0])
+	for _, e := range errs[1:] {
+		best = max(best, scorer(e))
+	}
+	return best
+}
+
+// ScoreByIs returns a scorer for Score and MaxScore built from a table of
+// target errors.
+//
+// The returned function checks pairs in order and returns the Points of the
+// first pair whose Target matches the error according to errors.Is (first
+// match wins). An error that matches no pair, as well as a nil error, scores
+// 0.
+func ScoreByIs(pairs ...ScorePair) func(error) int {
+	return func(err error) int {
+		if err == nil {
+			return 0
+		}
+		for _, p := range pairs {
+			if errors.Is(err, p.Target) {
+				return p.Points
+			}
+		}
+		return 0
+	}
+}
+
+// Worst returns the constituent of err that is greatest according to cmp.
+//
+// cmp(a, b) MUST return a negative number when a is less severe than b, a
+// positive number when it is more severe, and zero when both are equally
+// severe. When several constituents are equally severe, the first one in
+// Errors order is returned. This is useful for picking the single error to
+// surface to a user:
+//
+//	worst := rxmerr.Worst(err, func(a, b error) int {
+//	    return cmp.Compare(severityOf(a), severityOf(b))
+//	})
+//
+// If err is nil, Worst returns nil.
+func Worst(err error, cmp func(a, b error) int) error {
+	errs := Errors(err)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	worst := errs[0]
+	for _, e := range errs[1:] {
+		if cmp(e, worst) > 0 {
+			worst = e
+		}
+	}
+	return worst
+}
@@ -0,0 +1,131 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+// severityError carries a severity level, as a domain error type would.
+type severityError struct {
+	msg   string
+	level int
+}
+
+func (e *severityError) Error() string { return e.msg }
+
+func severityOf(err error) int {
+	var serr *severityError
+	if errors.As(err, &serr) {
+		return serr.level
+	}
+	return 0
+}
+
+func bySeverity(a, b error) int {
+	return cmp.Compare(severityOf(a), severityOf(b))
+}
+
+func TestWorst(t *testing.T) {
+	warn := &severityError{msg: "warn", level: 1}
+	crit := &severityError{msg: "crit", level: 3}
+	critToo := &severityError{msg: "crit too", level: 3}
+	plain := errors.New("plain")
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "nil", err: nil, want: nil},
+		{name: "single", err: plain, want: plain},
+		{name: "greatest wins", err: rxmerr.Combine(warn, crit, plain), want: crit},
+		{name: "first of equals", err: rxmerr.Combine(plain, critToo, warn, crit), want: critToo},
+		{name: "all equal", err: rxmerr.Combine(plain, errors.New("other")), want: plain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rxmerr.Worst(tt.err, bySeverity); got != tt.want {
+				t.Errorf("Worst() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScore(t *testing.T) {
+	errDataLoss := errors.New("data loss")
+	errTimeout := errors.New("timeout")
+	scorer := rxmerr.ScoreByIs(
+		rxmerr.ScorePair{Target: errDataLoss, Points: 100},
+		rxmerr.ScorePair{Target: errTimeout, Points: 5},
+		rxmerr.ScorePair{Target: errDataLoss, Points: 1},
+	)
+	nested := rxmerr.Combine(
+		errTimeout,
+		rxmerr.Combine(fmt.Errorf("write: %w", errDataLoss), errTimeout),
+		io.EOF,
+	)
+
+	tests := []struct {
+		name    string
+		err     error
+		wantSum int
+		wantMax int
+	}{
+		{name: "nil", err: nil},
+		{name: "no match", err: rxmerr.Combine(io.EOF, io.ErrClosedPipe)},
+		{name: "single", err: errTimeout, wantSum: 5, wantMax: 5},
+		{name: "first pair wins", err: errDataLoss, wantSum: 100, wantMax: 100},
+		{name: "wrapped sentinel", err: fmt.Errorf("flush: %w", errTimeout), wantSum: 5, wantMax: 5},
+		{name: "nested aggregates", err: nested, wantSum: 110, wantMax: 100},
+		{name: "repeats", err: rxmerr.Combine(errTimeout, errTimeout, errTimeout), wantSum: 15, wantMax: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rxmerr.Score(tt.err, scorer); got != tt.wantSum {
+				t.Errorf("Score() = %d, want %d", got, tt.wantSum)
+			}
+			if got := rxmerr.MaxScore(tt.err, scorer); got != tt.wantMax {
+				t.Errorf("MaxScore() = %d, want %d", got, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestScoreByIs(t *testing.T) {
+	scorer := rxmerr.ScoreByIs(rxmerr.ScorePair{Target: io.EOF, Points: 3})
+	if got := scorer(nil); got != 0 {
+		t.Errorf("scorer(nil) = %d, want 0", got)
+	}
+	if got := rxmerr.ScoreByIs()(io.EOF); got != 0 {
+		t.Errorf("ScoreByIs()(io.EOF) = %d, want 0", got)
+	}
+
+	// MaxScore keeps negative scores: it does not start from 0.
+	negative := rxmerr.ScoreByIs(rxmerr.ScorePair{Target: io.EOF, Points: -2})
+	if got := rxmerr.MaxScore(rxmerr.Combine(io.EOF, io.EOF), negative); got != -2 {
+		t.Errorf("MaxScore() with negative points = %d, want -2", got)
+	}
+}
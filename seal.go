@@ -0,0 +1,80 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import "fmt"
+
+// SealPolicy determines what happens when an error is appended to a sealed
+// Collector. See Collector.Seal.
+type SealPolicy int
+
+const (
+	// PanicOnSeal makes appends to a sealed collector panic. It is the
+	// default, as such appends usually indicate a bug.
+	PanicOnSeal SealPolicy = iota
+
+	// DropOnSeal makes a sealed collector silently discard appended errors.
+	DropOnSeal
+)
+
+// WithSealPolicy returns an option that sets the policy applied to appends
+// after the collector was sealed. The default is PanicOnSeal.
+func WithSealPolicy(p SealPolicy) Option {
+	return func(c *Collector) {
+		c.sealPolicy = p
+	}
+}
+
+// Seal makes the collector read-only for appends, for example at the end of
+// an initialization phase:
+//
+//	c := rxmerr.NewCollector()
+//	c.Append(loadConfig())
+//	c.Append(openDB())
+//	c.Seal()
+//	...
+//	c.Append(err) // panics: the collection phase is over
+//
+// After Seal, every non-nil error appended through any Append method, or
+// forwarded from a child created with Wrap, is handled according to the
+// collector's SealPolicy: with PanicOnSeal the call panics, with DropOnSeal
+// the error is discarded without being stored or counted. Inspection methods
+// keep working. Appending nil is always allowed.
+//
+// Sealing only guards appends; the sealed state survives Reset. Use Unseal to
+// re-open the collector.
+func (c *Collector) Seal() {
+	c.sealed = true
+}
+
+// Unseal re-opens a collector sealed with Seal for appending.
+func (c *Collector) Unseal() {
+	c.sealed = false
+}
+
+// IsSealed reports whether the collector is sealed. See Seal.
+func (c *Collector) IsSealed() bool {
+	return c.sealed
+}
+
+// rejectSealed applies the seal policy to err, which was appended to a sealed
+// collector and will not be stored.
+func (c *Collector) rejectSealed(err error) {
+	if c.sealPolicy == PanicOnSeal {
+		panic(fmt.Sprintf("rxmerr: append to sealed Collector: %v", err))
+	}
+}
@@ -0,0 +1,134 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+// appendPanics calls c.Append(err) and returns the recovered panic value, or
+// nil if it did not panic.
+func appendPanics(c *rxmerr.Collector, err error) (r any) {
+	defer func() { r = recover() }()
+	c.Append(err)
+	return nil
+}
+
+func TestSealDefaultPolicy(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(io.EOF)
+	c.Seal()
+	if !c.IsSealed() {
+		t.Fatal("IsSealed() after Seal = false, want true")
+	}
+
+	c.Append(nil)
+	r := appendPanics(c, errors.New("late"))
+	if msg, ok := r.(string); !ok || msg != "rxmerr: append to sealed Collector: late" {
+		t.Errorf("Append() on sealed collector panicked with %#v, want the sealed message", r)
+	}
+	if got := c.Errors(); !slices.Equal(got, []error{io.EOF}) || c.Len() != 1 {
+		t.Errorf("Errors() = %v, Len() = %d, want [EOF] and 1", got, c.Len())
+	}
+}
+
+func TestSealPolicies(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    rxmerr.SealPolicy
+		wantPanic bool
+	}{
+		{name: "panic", policy: rxmerr.PanicOnSeal, wantPanic: true},
+		{name: "drop", policy: rxmerr.DropOnSeal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := rxmerr.NewCollector(rxmerr.WithSealPolicy(tt.policy))
+			c.Append(io.EOF)
+			c.Seal()
+
+			if got := appendPanics(c, errors.New("late")) != nil; got != tt.wantPanic {
+				t.Errorf("Append() on sealed collector panicked = %v, want %v", got, tt.wantPanic)
+			}
+			if got := c.Errors(); !slices.Equal(got, []error{io.EOF}) || c.Len() != 1 {
+				t.Errorf("Errors() = %v, Len() = %d, want [EOF] and 1", got, c.Len())
+			}
+		})
+	}
+}
+
+func TestSealForwardedFromWrap(t *testing.T) {
+	c := rxmerr.NewCollector(rxmerr.WithSealPolicy(rxmerr.DropOnSeal))
+	child := c.Wrap("child")
+	c.Seal()
+
+	child.Append(io.EOF)
+	if c.HasError() {
+		t.Errorf("Err() after forwarding to a sealed collector = %v, want nil", c.Err())
+	}
+}
+
+func TestUnseal(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Seal()
+	c.Unseal()
+	if c.IsSealed() {
+		t.Fatal("IsSealed() after Unseal = true, want false")
+	}
+
+	if r := appendPanics(c, io.EOF); r != nil {
+		t.Fatalf("Append() after Unseal panicked with %v", r)
+	}
+	if got := c.Len(); got != 1 {
+		t.Errorf("Len() after Unseal = %d, want 1", got)
+	}
+}
+
+func TestSealSurvivesReset(t *testing.T) {
+	c := rxmerr.NewCollector(rxmerr.WithSealPolicy(rxmerr.DropOnSeal))
+	c.Append(io.EOF)
+	c.Seal()
+	c.Reset()
+
+	if !c.IsSealed() {
+		t.Fatal("IsSealed() after Reset = false, want true")
+	}
+	c.Append(io.ErrUnexpectedEOF)
+	if c.HasError() {
+		t.Errorf("Err() after Reset of a sealed collector = %v, want nil", c.Err())
+	}
+}
+
+func TestSealInSuccessMode(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(io.EOF)
+	c.Seal()
+	c.SetSuccess()
+
+	if r := appendPanics(c, errors.New("late")); r != nil {
+		t.Fatalf("Append() on sealed collector in success mode panicked with %v", r)
+	}
+	if got := c.Errors(); !slices.Equal(got, []error{io.EOF}) {
+		t.Errorf("Errors() = %v, want [EOF]", got)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// AppendSentinel appends err flagged as expected, such as io.EOF or
+// context.Canceled.
+//
+// Flagged errors are collected like any other error: they are counted by Len
+// and included in Err and Errors. In addition, they can be told apart from
+// unexpected errors with SentinelErrors, NonSentinelErrors, and
+// HasOnlySentinels:
+//
+//	c.AppendSentinel(ctx.Err())
+//	c.Append(flush())
+//	if c.HasOnlySentinels() {
+//	    return nil // nothing unexpected happened
+//	}
+//	return c.Err()
+//
+// The flag does not change the message of err, and err remains reachable via
+// errors.Is and errors.As. It survives the collector's options (see Option),
+// as long as transforms wrap errors rather than replace them. A nil err is
+// ignored.
+func (c *Collector) AppendSentinel(err error) {
+	if err == nil {
+		return
+	}
+	c.Append(sentinelError{err: err})
+}
+
+// SentinelErrors returns the collected errors that were appended with
+// AppendSentinel, in the order returned by Errors, or nil if there are none.
+func (c *Collector) SentinelErrors() []error {
+	return c.filterSentinels(true)
+}
+
+// NonSentinelErrors returns the collected errors that were not appended with
+// AppendSentinel, in the order returned by Errors, or nil if there are none.
+func (c *Collector) NonSentinelErrors() []error {
+	return c.filterSentinels(false)
+}
+
+// HasOnlySentinels reports whether at least one error was collected and all
+// collected errors were appended with AppendSentinel.
+func (c *Collector) HasOnlySentinels() bool {
+	return c.HasError() && c.NonSentinelErrors() == nil
+}
+
+// filterSentinels returns the collected errors whose sentinel flag equals
+// sentinel.
+func (c *Collector) filterSentinels(sentinel bool) []error {
+	var out []error
+	for _, err := range c.Errors() {
+		if isSentinel(err) == sentinel {
+			out = append(out, err)
+		}
+	}
+	return out
+}
+
+// isSentinel reports whether err was flagged by AppendSentinel.
+func isSentinel(err error) bool {
+	var s sentinelError
+	return errors.As(err, &s)
+}
+
+// sentinelError flags an error appended with AppendSentinel. It is a value
+// type so that repeated sentinels compare equal and are interned.
+type sentinelError struct {
+	err error
+}
+
+func (e sentinelError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the flagged error.
+func (e sentinelError) Unwrap() error {
+	return e.err
+}
+
+// Format implements fmt.Formatter: %+v formats the flagged error with %+v,
+// %q quotes Error(), and other verbs write Error().
+func (e sentinelError) Format(f fmt.State, verb rune) {
+	formatWrapper(f, verb, e, "", e.err)
+}
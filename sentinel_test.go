@@ -0,0 +1,88 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestAppendSentinel(t *testing.T) {
+	errFlush := errors.New("flush failed")
+	c := rxmerr.NewCollector()
+	c.AppendSentinel(io.EOF)
+	c.AppendSentinel(nil)
+	c.Append(errFlush)
+	c.AppendSentinel(context.Canceled)
+
+	if got := c.Len(); got != 3 {
+		t.Errorf("Len() = %d, want 3", got)
+	}
+	if got, want := c.Err().Error(), "EOF; flush failed; context canceled"; got != want {
+		t.Errorf("Err() = %q, want %q", got, want)
+	}
+
+	sentinels := c.SentinelErrors()
+	if len(sentinels) != 2 || !errors.Is(sentinels[0], io.EOF) || !errors.Is(sentinels[1], context.Canceled) {
+		t.Errorf("SentinelErrors() = %v, want [EOF context canceled]", sentinels)
+	}
+	if got, want := c.NonSentinelErrors(), []error{errFlush}; !slices.Equal(got, want) {
+		t.Errorf("NonSentinelErrors() = %v, want %v", got, want)
+	}
+	if c.HasOnlySentinels() {
+		t.Error("HasOnlySentinels() = true with an unexpected error collected")
+	}
+}
+
+func TestHasOnlySentinels(t *testing.T) {
+	c := rxmerr.NewCollector()
+	if c.HasOnlySentinels() {
+		t.Error("HasOnlySentinels() on empty collector = true, want false")
+	}
+
+	c.AppendSentinel(io.EOF)
+	c.AppendSentinel(io.EOF)
+	if !c.HasOnlySentinels() {
+		t.Error("HasOnlySentinels() = false with only sentinels collected")
+	}
+	if got := c.NonSentinelErrors(); got != nil {
+		t.Errorf("NonSentinelErrors() = %v, want nil", got)
+	}
+}
+
+func TestAppendSentinelWithOptions(t *testing.T) {
+	c := rxmerr.NewCollector(rxmerr.WithTransform(func(err error) error {
+		return fmt.Errorf("step: %w", err)
+	}))
+	c.AppendSentinel(io.EOF)
+
+	if !c.HasOnlySentinels() {
+		t.Error("HasOnlySentinels() = false: the flag did not survive a wrapping transform")
+	}
+	if got, want := c.Messages(), []string{"step: EOF"}; !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	if got := fmt.Sprintf("%+v", c.SentinelErrors()[0]); got != "step: EOF" {
+		t.Errorf("%%+v = %q, want %q", got, "step: EOF")
+	}
+}
@@ -0,0 +1,227 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// messageBytes returns the size of err's message in bytes. It is the single
+// measurement used by all size accounting in this package.
+func messageBytes(err error) int {
+	return len(err.Error())
+}
+
+// ByteLen returns the total size, in bytes, of the messages of all
+// constituents of err.
+//
+// Sizes are measured in bytes of the UTF-8 encoded Error() strings, not in
+// runes: a message consisting of a single "€" counts as 3. Separators that
+// Error() of the aggregate itself would add are not included. If err is nil,
+// ByteLen returns 0.
+func ByteLen(err error) int {
+	total := 0
+	for _, e := range Errors(err) {
+		total += messageBytes(e)
+	}
+	return total
+}
+
+// WithMaxBytes returns an option that bounds the total size of the messages
+// stored by a collector to n bytes.
+//
+// An error is stored only if the sizes of the stored messages, including its
+// own, add up to at most n bytes (measured like ByteLen). Errors that do not
+// fit are dropped: they are still counted by Len, but are not stored, and the
+// size of their messages is added to DroppedBytes. This guards against
+// dependencies that embed huge payloads in error messages.
+//
+// The bound is a storage stage that applies last in the pipeline, see Option.
+// A non-positive n disables the bound.
+func WithMaxBytes(n int) Option {
+	return func(c *Collector) {
+		c.maxBytes = n
+	}
+}
+
+// DroppedBytes returns the total size, in bytes, of the messages of errors
+// that were dropped because of WithMaxBytes.
+func (c *Collector) DroppedBytes() int {
+	return c.droppedBytes
+}
+
+// fitsMaxBytes reports whether err fits within the WithMaxBytes bound and
+// accounts for it: the size is added to the stored bytes if it fits, and to
+// the dropped bytes otherwise.
+func (c *Collector) fitsMaxBytes(err error) bool {
+	if c.maxBytes <= 0 {
+		return true
+	}
+
+	n := messageBytes(err)
+	if c.storedBytes+n > c.maxBytes {
+		c.droppedBytes += n
+		return false
+	}
+	c.storedBytes += n
+	return true
+}
+
+// TruncateMessages returns an aggregate of the constituents of err in which
+// every message longer than maxLen runes is cut to its first maxLen runes
+// followed by an ellipsis ("…").
+//
+// Constituents whose messages fit are kept as-is. Each truncated constituent
+// is replaced with an error that carries the shortened message and unwraps to
+// the original, so errors.Is and errors.As keep matching it, as for
+// TruncateMessage. The original error, including its full message, is
+// retained in memory.
+//
+// If err is nil, TruncateMessages returns nil. A non-positive maxLen leaves
+// err unchanged.
+func TruncateMessages(err error, maxLen int) error {
+	if err == nil || maxLen <= 0 {
+		return err
+	}
+	return Combine(truncateAll(Errors(err), maxLen)...)
+}
+
+// TruncateMessages replaces the collected errors with their truncated
+// counterparts, as the TruncateMessages function does for an aggregate.
+//
+// Len is not affected. A non-positive maxLen leaves the collector unchanged.
+func (c *Collector) TruncateMessages(maxLen int) {
+	if len(c.errs) == 0 || maxLen <= 0 {
+		return
+	}
+
+	c.replaceAll(truncateAll(c.storedErrors(), maxLen))
+}
+
+// truncateAll truncates the messages of errs in place, as described by
+// TruncateMessages, and returns errs.
+func truncateAll(errs []error, maxLen int) []error {
+	for i, err := range errs {
+		if msg, ok := truncateRunes(err.Error(), maxLen); ok {
+			errs[i] = &truncatedError{err: err, msg: msg}
+		}
+	}
+	return errs
+}
+
+// truncateRunes cuts msg to its first maxLen runes followed by an ellipsis if
+// it is longer than maxLen runes, reporting whether it did.
+func truncateRunes(msg string, maxLen int) (string, bool) {
+	if utf8.RuneCountInString(msg) <= maxLen {
+		return msg, false
+	}
+	cut := 0
+	for range maxLen {
+		_, size := utf8.DecodeRuneInString(msg[cut:])
+		cut += size
+	}
+	return msg[:cut] + "…", true
+}
+
+// WithMaxMessageBytes returns an option that bounds the size of the message
+// of every individual error appended to a collector to n bytes.
+//
+// An error whose message is longer than n bytes is wrapped, as by
+// TruncateMessage, in an error whose message is cut to at most n bytes and
+// marked with the original size:
+//
+//	<first n bytes>…(truncated, 2.1MB total)
+//
+// The wrapper unwraps to the original error, so errors.Is and errors.As keep
+// working, and the original error, including its full message, is retained in
+// memory. WithMaxBytes accounts for the truncated message only; it bounds the
+// size of what Errors and Err render, not the memory retained by the
+// originals.
+//
+// Truncation is a transform stage, see Option. A non-positive n disables the
+// bound.
+func WithMaxMessageBytes(n int) Option {
+	return WithTransform(func(err error) error {
+		return TruncateMessage(err, n)
+	})
+}
+
+// TruncateMessage bounds the message of err to n bytes.
+//
+// If the message of err is longer than n bytes, TruncateMessage returns an
+// error whose message consists of the longest prefix of the original message
+// that is at most n bytes long and does not split a UTF-8 sequence, followed
+// by a marker with the original size, for example
+// "<!DOCTYPE html>…(truncated, 2.1MB total)". The returned error unwraps to
+// err. Otherwise, or if n is not positive, err is returned unchanged.
+func TruncateMessage(err error, n int) error {
+	if err == nil || n <= 0 {
+		return err
+	}
+	msg := err.Error()
+	if len(msg) <= n {
+		return err
+	}
+
+	cut := n
+	for cut > 0 && !utf8.RuneStart(msg[cut]) {
+		cut--
+	}
+	return &truncatedError{err: err, msg: msg[:cut] + "…(truncated, " + formatBytes(len(msg)) + " total)"}
+}
+
+// truncatedError is an error with a shortened message, as returned by
+// TruncateMessage and TruncateMessages.
+type truncatedError struct {
+	err error
+	msg string
+}
+
+func (e *truncatedError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the original error.
+func (e *truncatedError) Unwrap() error {
+	return e.err
+}
+
+// Format implements fmt.Formatter: %+v writes the truncated message followed
+// by the detail of the original error, %q quotes Error(), and other verbs
+// write Error(). The full original message is never written. See
+// formatSummary.
+func (e *truncatedError) Format(f fmt.State, verb rune) {
+	formatSummary(f, verb, e, e.err)
+}
+
+// formatBytes renders n bytes with decimal (SI) units, for example "512B",
+// "3.4KB" or "2.1MB".
+func formatBytes(n int) string {
+	const unit = 1000
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	value, suffix := float64(n)/unit, "KB"
+	for _, next := range []string{"MB", "GB", "TB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return fmt.Sprintf("%.1f%s", value, suffix)
+}
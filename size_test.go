@@ -0,0 +1,176 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestByteLen(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: 0},
+		{name: "ascii", err: errors.New("abc"), want: 3},
+		// ByteLen counts bytes, not runes: "€" is one rune of 3 bytes.
+		{name: "multi-byte", err: errors.New("€"), want: 3},
+		{name: "mixed", err: errors.New("héllo wörld"), want: 13},
+		{
+			name: "aggregate without separators",
+			err:  rxmerr.Combine(errors.New("ab"), errors.New("日本")),
+			want: 2 + 6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rxmerr.ByteLen(tt.err); got != tt.want {
+				t.Errorf("ByteLen() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithMaxBytes(t *testing.T) {
+	c := rxmerr.NewCollector(rxmerr.WithMaxBytes(8))
+	c.Append(errors.New("€€"), errors.New("ab"), errors.New("€"), errors.New("c"))
+
+	// "€€" (6 bytes) and "ab" (2 bytes) fill the bound; "€" would exceed it
+	// by bytes although 3 runes would fit.
+	want := []string{"€€", "ab"}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	if got := c.Len(); got != 4 {
+		t.Errorf("Len() = %d, want 4", got)
+	}
+	if got := c.DroppedBytes(); got != 4 {
+		t.Errorf("DroppedBytes() = %d, want 4", got)
+	}
+}
+
+func TestTruncateMessages(t *testing.T) {
+	errLong := errors.New("upstream answered: <!DOCTYPE html><html>…")
+	errShort := errors.New("short")
+
+	err := rxmerr.TruncateMessages(rxmerr.Combine(errLong, errShort, errors.New("héllo wörld")), 8)
+
+	want := []string{"upstream…", "short", "héllo wö…"}
+	if got := rxmerr.Messages(err); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	if errs := rxmerr.Errors(err); errs[1] != errShort {
+		t.Errorf("short constituent = %v, want it kept as-is", errs[1])
+	}
+	if !errors.Is(err, errLong) {
+		t.Error("truncated constituent does not unwrap to the original")
+	}
+}
+
+func TestTruncateMessagesNoop(t *testing.T) {
+	err := rxmerr.Combine(errors.New("a"), errors.New("b"))
+	if got := rxmerr.TruncateMessages(err, 0); got != err {
+		t.Errorf("TruncateMessages(err, 0) = %v, want err unchanged", got)
+	}
+	if got := rxmerr.TruncateMessages(nil, 3); got != nil {
+		t.Errorf("TruncateMessages(nil, 3) = %v, want nil", got)
+	}
+}
+
+func TestCollectorTruncateMessages(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(errors.New("a very long message"), errors.New("ok"))
+	c.TruncateMessages(6)
+
+	want := []string{"a very…", "ok"}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+}
+
+func TestTruncateMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		n    int
+		want string
+	}{
+		{"short", "ok", 10, "ok"},
+		{"exact", "abcd", 4, "abcd"},
+		{"ascii", "abcdef", 3, "abc…(truncated, 6B total)"},
+		{"rune boundary", "ab€cd", 5, "ab€…(truncated, 7B total)"},
+		{"inside rune", "ab€cd", 4, "ab…(truncated, 7B total)"},
+		{"inside first rune", "€uro", 2, "…(truncated, 6B total)"},
+		{"four-byte rune", "a🙂b", 3, "a…(truncated, 6B total)"},
+		{"large", strings.Repeat("x", 2_100_000), 2, "xx…(truncated, 2.1MB total)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := errors.New(tt.msg)
+			err := rxmerr.TruncateMessage(orig, tt.n)
+			got := err.Error()
+			if got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("Error() = %q is not valid UTF-8", got)
+			}
+			if !errors.Is(err, orig) {
+				t.Errorf("errors.Is(TruncateMessage(), orig) = false")
+			}
+		})
+	}
+}
+
+func TestTruncateMessageNoop(t *testing.T) {
+	if err := rxmerr.TruncateMessage(nil, 3); err != nil {
+		t.Errorf("TruncateMessage(nil) = %v, want nil", err)
+	}
+	orig := errors.New("abcdef")
+	for _, n := range []int{0, -1, 6} {
+		if err := rxmerr.TruncateMessage(orig, n); err != orig {
+			t.Errorf("TruncateMessage(err, %d) = %#v, want err unchanged", n, err)
+		}
+	}
+}
+
+func TestWithMaxMessageBytes(t *testing.T) {
+	errHTML := fmt.Errorf("upstream: %w", errors.New("<!DOCTYPE html><html>…</html>"))
+	c := rxmerr.NewCollector(rxmerr.WithMaxMessageBytes(24))
+	c.Append(errHTML, io.EOF)
+
+	want := []string{"upstream: <!DOCTYPE html…(truncated, 41B total)", "EOF"}
+	if got := c.Messages(); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	if err := c.Err(); !errors.Is(err, errHTML) || !errors.Is(err, io.EOF) {
+		t.Errorf("Err() = %v, originals not reachable via errors.Is", err)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// SourceError associates an error with its source, such as a URL, a file
+// name, or a service name. It is created by Collector.AppendWithSource and
+// SafeCollector.AppendFrom.
+type SourceError struct {
+	Source string
+	Err    error
+}
+
+// Error returns the source followed by the message, for example
+// "billing-api: connection refused".
+func (e *SourceError) Error() string {
+	return e.Source + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// Format implements fmt.Formatter: %+v writes the source followed by the %+v
+// output of the underlying error, %q quotes Error(), and other verbs write
+// Error().
+func (e *SourceError) Format(f fmt.State, verb rune) {
+	formatWrapper(f, verb, e, e.Source+": ", e.Err)
+}
+
+// AppendWithSource appends err wrapped in a *SourceError, so that a collector
+// aggregating the errors of several endpoints, files, or services can tell
+// them apart:
+//
+//	for _, u := range urls {
+//	    c.AppendWithSource(u, fetch(u))
+//	}
+//	for _, u := range c.Sources() {
+//	    log.Printf("%s: %d errors", u, len(c.ErrorsBySource(u)))
+//	}
+//
+// A nil err is ignored.
+func (c *Collector) AppendWithSource(source string, err error) {
+	if err == nil {
+		return
+	}
+	c.Append(&SourceError{Source: source, Err: err})
+}
+
+// ErrorsBySource returns the collected errors whose source is source, in the
+// order returned by Errors, or nil if there are none. The source of an error
+// is that of the first *SourceError in its chain, as found by errors.As.
+func (c *Collector) ErrorsBySource(source string) []error {
+	var out []error
+	for _, err := range c.Errors() {
+		if got, ok := sourceOf(err); ok && got == source {
+			out = append(out, err)
+		}
+	}
+	return out
+}
+
+// Sources returns the distinct sources of the collected errors (see
+// ErrorsBySource), sorted lexically, or nil if no collected error has a
+// source.
+func (c *Collector) Sources() []string {
+	var sources []string
+	for _, err := range c.Errors() {
+		if source, ok := sourceOf(err); ok && !slices.Contains(sources, source) {
+			sources = append(sources, source)
+		}
+	}
+	slices.Sort(sources)
+	return sources
+}
+
+// sourceOf returns the source of the first *SourceError in the chain of err.
+func sourceOf(err error) (string, bool) {
+	var se *SourceError
+	if errors.As(err, &se) {
+		return se.Source, true
+	}
+	return "", false
+}
@@ -0,0 +1,98 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestAppendWithSource(t *testing.T) {
+	errA := errors.New("a")
+	c := rxmerr.NewCollector()
+	c.AppendWithSource("db", errA)
+	c.AppendWithSource("cache", nil)
+	c.AppendWithSource("api", io.EOF)
+	c.AppendWithSource("db", io.ErrUnexpectedEOF)
+	c.Append(errors.New("no source"))
+	c.Append(fmt.Errorf("retry: %w", &rxmerr.SourceError{Source: "queue", Err: io.EOF}))
+
+	if got := c.Len(); got != 5 {
+		t.Errorf("Len() = %d, want 5", got)
+	}
+	if got, want := c.Sources(), []string{"api", "db", "queue"}; !slices.Equal(got, want) {
+		t.Errorf("Sources() = %q, want %q", got, want)
+	}
+
+	tests := []struct {
+		source string
+		want   []string
+	}{
+		{source: "db", want: []string{"db: a", "db: unexpected EOF"}},
+		{source: "api", want: []string{"api: EOF"}},
+		{source: "queue", want: []string{"retry: queue: EOF"}},
+		{source: "cache"},
+		{source: ""},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, err := range c.ErrorsBySource(tt.source) {
+			got = append(got, err.Error())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ErrorsBySource(%q) = %q, want %q", tt.source, got, tt.want)
+		}
+	}
+
+	if !errors.Is(c.Err(), errA) {
+		t.Errorf("errors.Is(%v, a) = false, want true", c.Err())
+	}
+	var se *rxmerr.SourceError
+	if !errors.As(c.ErrorsBySource("db")[0], &se) || se.Source != "db" || se.Err != errA {
+		t.Errorf("errors.As(*SourceError) = %#v, want source db wrapping a", se)
+	}
+}
+
+func TestSourcesEmpty(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(io.EOF)
+	if got := c.Sources(); got != nil {
+		t.Errorf("Sources() = %q, want nil", got)
+	}
+}
+
+func TestSourceErrorFormat(t *testing.T) {
+	err := &rxmerr.SourceError{Source: "billing-api", Err: &stackErr{"a"}}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "%v", want: "billing-api: a"},
+		{format: "%q", want: `"billing-api: a"`},
+		{format: "%+v", want: "billing-api: a\nmain.run\n\tmain.go:12"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, err); got != tt.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Stage is a named Collector holding the errors of one stage of a pipeline.
+// See MergeStages.
+type Stage struct {
+	Name string
+	C    *Collector
+}
+
+// MergeStages combines the errors collected by the stages of a pipeline, in
+// the order of stages, into a single aggregate.
+//
+// Each constituent is wrapped with the name of its stage, like
+// fmt.Errorf("%s: %w", name, err), so the compact form reads
+// "authn: invalid token; upstream: dial tcp: i/o timeout". The wrapped errors
+// remain reachable via errors.Is and errors.As. The order follows stages,
+// not the order in which the stages collected their errors, and stages
+// without errors (or with a nil C) are omitted. If no stage collected an
+// error, MergeStages returns nil.
+//
+// Format, and %+v, render the aggregate grouped by stage, with the
+// constituents numbered as by Errors:
+//
+//	3 errors occurred:
+//	  authn:
+//	    [0] invalid token
+//	  upstream:
+//	    [1] dial tcp 10.0.0.7:443: i/o timeout
+//	    [2] retry budget exhausted
+//
+// MergeStages composes collectors after the fact, which suits stages owned by
+// different packages; Collector.Wrap is the alternative when a parent can
+// hand out child collectors up front. The stages' own context messages (see
+// SetContext) are not applied; the stage name takes their place. See also
+// Pipeline.
+func MergeStages(stages []Stage) error {
+	agg := &stagesError{}
+	for _, s := range stages {
+		if s.C == nil {
+			continue
+		}
+		errs := s.C.Errors()
+		if len(errs) == 0 {
+			continue
+		}
+		agg.groups = append(agg.groups, stageGroup{name: s.Name, errs: errs})
+		for _, err := range errs {
+			agg.errs = append(agg.errs, &annotatedError{msg: s.Name, err: err})
+		}
+	}
+	if len(agg.errs) == 0 {
+		return nil
+	}
+	return agg
+}
+
+// Pipeline collects errors in named stages and merges them with MergeStages.
+//
+//	p := rxmerr.NewPipeline()
+//	authn.Check(req, p.Stage("authn"))
+//	route, _ := router.Match(req, p.Stage("routing"))
+//	...
+//	if err := p.Err(); err != nil {
+//	    log.Printf("request failed:\n%s", rxmerr.Format(err))
+//	}
+//
+// Stages are ordered by the first call of Stage for their name. The zero
+// value is an empty Pipeline ready for use, whose stage collectors have no
+// options.
+type Pipeline struct {
+	opts   []Option
+	stages []Stage
+}
+
+// NewPipeline creates a new Pipeline whose stage collectors are created with
+// opts.
+func NewPipeline(opts ...Option) *Pipeline {
+	return &Pipeline{opts: opts}
+}
+
+// Stage returns the collector of the stage named name, creating the stage
+// after the existing ones if it does not exist yet.
+func (p *Pipeline) Stage(name string) *Collector {
+	for _, s := range p.stages {
+		if s.Name == name {
+			return s.C
+		}
+	}
+	c := NewCollector(p.opts...)
+	p.stages = append(p.stages, Stage{Name: name, C: c})
+	return c
+}
+
+// Stages returns the stages of the pipeline, in order.
+func (p *Pipeline) Stages() []Stage {
+	return append([]Stage(nil), p.stages...)
+}
+
+// Err returns MergeStages of the pipeline's stages.
+func (p *Pipeline) Err() error {
+	return MergeStages(p.stages)
+}
+
+// stageGroup holds the errors of one stage of a stagesError, without the
+// stage name.
+type stageGroup struct {
+	name string
+	errs []error
+}
+
+// stagesError is the aggregate returned by MergeStages.
+type stagesError struct {
+	groups []stageGroup
+	errs   []error // constituents, wrapped with their stage names
+}
+
+func (e *stagesError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the constituents of the aggregate.
+func (e *stagesError) Unwrap() []error {
+	return append([]error(nil), e.errs...)
+}
+
+// Format implements fmt.Formatter: %+v writes the listing grouped by stage,
+// see MergeStages, %q quotes Error(), and other verbs write Error().
+func (e *stagesError) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('+') {
+		e.writeGrouped(f)
+		return
+	}
+	formatCompact(f, verb, e)
+}
+
+// writeGrouped writes the listing of the constituents grouped by stage.
+// Numbering and indentation of constituents follow writeVerbose.
+func (e *stagesError) writeGrouped(w io.Writer) {
+	if len(e.errs) == 1 {
+		io.WriteString(w, "1 error occurred:")
+	} else {
+		fmt.Fprintf(w, "%d errors occurred:", len(e.errs))
+	}
+	i := 0
+	for _, g := range e.groups {
+		io.WriteString(w, "\n  "+g.name+":")
+		for _, err := range g.errs {
+			prefix := fmt.Sprintf("    [%d] ", i)
+			indent := strings.Repeat(" ", len(prefix))
+			detail := strings.ReplaceAll(fmt.Sprintf("%+v", err), "\n", "\n"+indent)
+			io.WriteString(w, "\n"+prefix+detail)
+			i++
+		}
+	}
+}
@@ -0,0 +1,143 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestMergeStages(t *testing.T) {
+	errToken := errors.New("invalid token")
+	authn, routing, upstream := rxmerr.NewCollector(), rxmerr.NewCollector(), rxmerr.NewCollector()
+
+	// Later stages finish collecting first.
+	upstream.Append(errors.New("dial tcp 10.0.0.7:443: i/o timeout"))
+	upstream.Append(&stackErr{msg: "retry budget exhausted"})
+	authn.Append(errToken)
+	routing.Append(nil)
+
+	err := rxmerr.MergeStages([]rxmerr.Stage{
+		{Name: "authn", C: authn},
+		{Name: "routing", C: routing},
+		{Name: "missing"},
+		{Name: "upstream", C: upstream},
+	})
+
+	want := []string{
+		"authn: invalid token",
+		"upstream: dial tcp 10.0.0.7:443: i/o timeout",
+		"upstream: retry budget exhausted",
+	}
+	if got := rxmerr.Messages(err); !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	if got, want := err.Error(), "authn: invalid token; upstream: dial tcp 10.0.0.7:443: i/o timeout; upstream: retry budget exhausted"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, errToken) {
+		t.Error("errors.Is(MergeStages(), errToken) = false")
+	}
+
+	grouped := "3 errors occurred:\n" +
+		"  authn:\n" +
+		"    [0] invalid token\n" +
+		"  upstream:\n" +
+		"    [1] dial tcp 10.0.0.7:443: i/o timeout\n" +
+		"    [2] retry budget exhausted\n" +
+		"        main.run\n" +
+		"        \tmain.go:12"
+	if got := rxmerr.Format(err); got != grouped {
+		t.Errorf("Format() = %q, want %q", got, grouped)
+	}
+	if got := fmt.Sprintf("%+v", err); got != grouped {
+		t.Errorf("%%+v = %q, want %q", got, grouped)
+	}
+	if got := fmt.Sprintf("%q", err); got != fmt.Sprintf("%q", err.Error()) {
+		t.Errorf("%%q = %s, want the quoted compact form", got)
+	}
+}
+
+func TestMergeStagesEmpty(t *testing.T) {
+	tests := []struct {
+		name   string
+		stages []rxmerr.Stage
+	}{
+		{"no stages", nil},
+		{"nil collectors", []rxmerr.Stage{{Name: "a"}, {Name: "b"}}},
+		{"no errors", []rxmerr.Stage{{Name: "a", C: rxmerr.NewCollector()}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rxmerr.MergeStages(tt.stages); err != nil {
+				t.Errorf("MergeStages() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestMergeStagesSingle(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(io.EOF)
+	err := rxmerr.MergeStages([]rxmerr.Stage{{Name: "read", C: c}})
+
+	if got, want := rxmerr.Format(err), "1 error occurred:\n  read:\n    [0] EOF"; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	p := rxmerr.NewPipeline(rxmerr.WithIgnore(func(err error) bool { return errors.Is(err, io.EOF) }))
+	if err := p.Err(); err != nil {
+		t.Fatalf("Err() on empty pipeline = %v, want nil", err)
+	}
+
+	upstream := p.Stage("upstream")
+	authn := p.Stage("authn")
+	if p.Stage("upstream") != upstream {
+		t.Error("Stage() returned a new collector for an existing stage")
+	}
+
+	authn.Append(errors.New("invalid token"))
+	upstream.Append(io.EOF, errors.New("timeout"))
+
+	want := []string{"upstream: timeout", "authn: invalid token"}
+	if got := rxmerr.Messages(p.Err()); !slices.Equal(got, want) {
+		t.Errorf("Messages(Err()) = %q, want %q", got, want)
+	}
+
+	var names []string
+	for _, s := range p.Stages() {
+		names = append(names, s.Name)
+	}
+	if want := []string{"upstream", "authn"}; !slices.Equal(names, want) {
+		t.Errorf("Stages() names = %q, want %q", names, want)
+	}
+}
+
+func TestPipelineZeroValue(t *testing.T) {
+	var p rxmerr.Pipeline
+	p.Stage("a").Append(io.EOF)
+	if got, want := p.Err().Error(), "a: EOF"; got != want {
+		t.Errorf("Err() = %q, want %q", got, want)
+	}
+}
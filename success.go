@@ -0,0 +1,60 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+// SuccessMode sets or clears the collector's success mode and returns c.
+//
+// While the collector is in success mode, every Append method is a no-op:
+// errors are neither stored, counted, nor forwarded to piped collectors.
+// Errors collected before the collector entered success mode are kept. This
+// suits "try each fallback in order" patterns, where the failures of the
+// fallbacks that were not needed are irrelevant once one succeeded:
+//
+//	c := rxmerr.NewCollector()
+//	for _, src := range sources {
+//	    v, err := src.Load()
+//	    if err == nil {
+//	        c.SetSuccess()
+//	        use(v)
+//	    }
+//	    c.Append(err)
+//	}
+//	if !c.HasSuccess() {
+//	    return c.Err()
+//	}
+//
+// Success mode takes precedence over Seal: a sealed collector in success mode
+// silently discards appended errors whatever its SealPolicy. Reset clears
+// success mode.
+func (c *Collector) SuccessMode(success bool) *Collector {
+	c.success = success
+	return c
+}
+
+// SetSuccess puts the collector in success mode, see SuccessMode. It reports
+// whether the collector was not in success mode before, so that callers can
+// act on the first success only.
+func (c *Collector) SetSuccess() bool {
+	first := !c.success
+	c.SuccessMode(true)
+	return first
+}
+
+// HasSuccess reports whether the collector is in success mode.
+func (c *Collector) HasSuccess() bool {
+	return c.success
+}
@@ -0,0 +1,93 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestSuccessMode(t *testing.T) {
+	errA := errors.New("a")
+	c := rxmerr.NewCollector()
+	c.Append(errA)
+
+	if !c.SetSuccess() {
+		t.Error("first SetSuccess() = false, want true")
+	}
+	if c.SetSuccess() {
+		t.Error("second SetSuccess() = true, want false")
+	}
+	if !c.HasSuccess() {
+		t.Error("HasSuccess() = false after SetSuccess")
+	}
+
+	c.Append(io.EOF)
+	c.AppendWeighted(5, io.EOF)
+	if got, want := c.Errors(), []error{errA}; !slices.Equal(got, want) {
+		t.Errorf("Errors() in success mode = %v, want the errors collected before: %v", got, want)
+	}
+	if got := c.Len(); got != 1 {
+		t.Errorf("Len() = %d, want 1", got)
+	}
+
+	if c.SuccessMode(false) != c {
+		t.Error("SuccessMode() did not return its receiver")
+	}
+	c.Append(io.EOF)
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len() after leaving success mode = %d, want 2", got)
+	}
+}
+
+func TestSuccessModeReset(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.SetSuccess()
+	c.Reset()
+
+	if c.HasSuccess() {
+		t.Error("HasSuccess() after Reset = true, want false")
+	}
+	c.Append(io.EOF)
+	if !c.HasError() {
+		t.Error("HasError() = false after appending following Reset")
+	}
+}
+
+func TestSuccessModeFallbacks(t *testing.T) {
+	results := []error{errors.New("primary down"), nil, errors.New("unused fallback")}
+
+	c := rxmerr.NewCollector()
+	successes := 0
+	for _, err := range results {
+		if err == nil && c.SetSuccess() {
+			successes++
+		}
+		c.Append(err)
+	}
+
+	if successes != 1 {
+		t.Errorf("first successes = %d, want 1", successes)
+	}
+	if got, want := c.Messages(), []string{"primary down"}; !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+}
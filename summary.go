@@ -0,0 +1,140 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// SummaryRule is a category of errors counted by Summarize.
+type SummaryRule struct {
+	// Label names one error of the category, for example "timeout".
+	Label string
+
+	// Plural names several errors of the category. If empty, it is derived
+	// from Label with regular English rules ("timeout" becomes "timeouts",
+	// "retry" becomes "retries"); labels that are not nouns, such as
+	// "connection refused", SHOULD set Plural explicitly.
+	Plural string
+
+	// Target, if not nil, makes the rule match errors for which
+	// errors.Is(err, Target) reports true.
+	Target error
+
+	// Match, if not nil, makes the rule match errors for which it reports
+	// true. If both Target and Match are set, an error matching either
+	// belongs to the category.
+	Match func(error) bool
+}
+
+// matches reports whether err belongs to the category of r.
+func (r SummaryRule) matches(err error) bool {
+	return (r.Target != nil && errors.Is(err, r.Target)) || (r.Match != nil && r.Match(err))
+}
+
+// phrase returns the counted phrase for n errors of the category, such as
+// "1 timeout" or "3 timeouts".
+func (r SummaryRule) phrase(n int) string {
+	label := r.Label
+	if n != 1 {
+		label = r.Plural
+		if label == "" {
+			label = pluralize(r.Label)
+		}
+	}
+	return strconv.Itoa(n) + " " + label
+}
+
+// Summarize returns a one-line summary of the constituents of err (see
+// Errors) counted by category:
+//
+//	rules := []rxmerr.SummaryRule{
+//	    {Label: "timeout", Target: context.DeadlineExceeded},
+//	    {Label: "connection refused", Plural: "connection refused", Target: syscall.ECONNREFUSED},
+//	}
+//	rxmerr.Summarize(err, rules) // "3 timeouts, 2 connection refused, 1 other"
+//
+// Each constituent is counted in the first rule that matches it, or in a
+// final "other" bucket if none does. Phrases appear in rule order, followed
+// by the other bucket; categories without errors are omitted. If err is nil,
+// Summarize returns an empty string.
+func Summarize(err error, rules []SummaryRule) string {
+	counts := make([]int, len(rules))
+	other := 0
+	for _, e := range Errors(err) {
+		matched := false
+		for i, r := range rules {
+			if r.matches(e) {
+				counts[i]++
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			other++
+		}
+	}
+
+	var phrases []string
+	for i, r := range rules {
+		if counts[i] > 0 {
+			phrases = append(phrases, r.phrase(counts[i]))
+		}
+	}
+	if other > 0 {
+		phrases = append(phrases, strconv.Itoa(other)+" other")
+	}
+	return strings.Join(phrases, ", ")
+}
+
+// WrapSummarized returns an aggregate with the same constituents as err whose
+// message is prefix followed by Summarize(err, rules):
+//
+//	err = rxmerr.WrapSummarized(err, "upstream selection failed", rules)
+//	err.Error() // "upstream selection failed: 3 timeouts, 2 connection refused, 1 other"
+//
+// The constituents remain available via Errors, errors.Is, and errors.As.
+// %+v writes the message followed by a listing of the constituents, like for
+// Errorf; the package-level Format lists the constituents alone, as it does
+// for err. If prefix is empty, the message is the summary alone. If err is
+// nil, WrapSummarized returns nil.
+func WrapSummarized(err error, prefix string, rules []SummaryRule) error {
+	if err == nil {
+		return nil
+	}
+	msg := Summarize(err, rules)
+	if prefix != "" {
+		msg = prefix + ": " + msg
+	}
+	return &formattedError{msg: msg, errs: Errors(err)}
+}
+
+// pluralize returns the regular English plural of the noun phrase s.
+func pluralize(s string) string {
+	switch {
+	case s == "":
+		return s
+	case strings.HasSuffix(s, "s"), strings.HasSuffix(s, "x"), strings.HasSuffix(s, "z"),
+		strings.HasSuffix(s, "ch"), strings.HasSuffix(s, "sh"):
+		return s + "es"
+	case strings.HasSuffix(s, "y") && len(s) > 1 && !strings.ContainsRune("aeiou", rune(s[len(s)-2])):
+		return s[:len(s)-1] + "ies"
+	}
+	return s + "s"
+}
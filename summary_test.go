@@ -0,0 +1,125 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestSummarize(t *testing.T) {
+	errRefused := errors.New("connection refused")
+	rules := []rxmerr.SummaryRule{
+		{Label: "timeout", Target: context.DeadlineExceeded},
+		{Label: "connection refused", Plural: "connection refused", Target: errRefused},
+		{Label: "retry", Match: func(err error) bool { return err.Error() == "retry" }},
+	}
+	timeout := fmt.Errorf("dial: %w", context.DeadlineExceeded)
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "nil", err: nil, want: ""},
+		{name: "single", err: timeout, want: "1 timeout"},
+		{name: "rule order", err: rxmerr.Combine(errRefused, timeout, io.EOF, timeout), want: "2 timeouts, 1 connection refused, 1 other"},
+		{name: "explicit plural", err: rxmerr.Combine(errRefused, errRefused), want: "2 connection refused"},
+		{name: "derived plural", err: rxmerr.Combine(errors.New("retry"), errors.New("retry")), want: "2 retries"},
+		{name: "other only", err: rxmerr.Combine(io.EOF, io.ErrClosedPipe), want: "2 other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rxmerr.Summarize(tt.err, rules); got != tt.want {
+				t.Errorf("Summarize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeFirstRuleWins(t *testing.T) {
+	rules := []rxmerr.SummaryRule{
+		{Label: "EOF", Plural: "EOF", Target: io.EOF},
+		{Label: "error", Match: func(error) bool { return true }},
+	}
+	err := rxmerr.Combine(io.EOF, errors.New("a"))
+	if got, want := rxmerr.Summarize(err, rules), "1 EOF, 1 error"; got != want {
+		t.Errorf("Summarize() = %q, want %q", got, want)
+	}
+}
+
+func TestWrapSummarized(t *testing.T) {
+	errA := errors.New("a")
+	rules := []rxmerr.SummaryRule{{Label: "EOF", Plural: "EOF", Target: io.EOF}}
+	combined := rxmerr.Combine(io.EOF, errA, io.EOF)
+
+	tests := []struct {
+		prefix string
+		want   string
+	}{
+		{prefix: "sync failed", want: "sync failed: 2 EOF, 1 other"},
+		{prefix: "", want: "2 EOF, 1 other"},
+	}
+	for _, tt := range tests {
+		err := rxmerr.WrapSummarized(combined, tt.prefix, rules)
+		if got := err.Error(); got != tt.want {
+			t.Errorf("WrapSummarized(%q).Error() = %q, want %q", tt.prefix, got, tt.want)
+		}
+		if got := rxmerr.Errors(err); !slices.Equal(got, []error{io.EOF, errA, io.EOF}) {
+			t.Errorf("Errors(WrapSummarized(%q)) = %v, want the constituents of err", tt.prefix, got)
+		}
+		if !errors.Is(err, errA) || !errors.Is(err, io.EOF) {
+			t.Errorf("WrapSummarized(%q) = %v, constituents not reachable via errors.Is", tt.prefix, err)
+		}
+	}
+
+	if err := rxmerr.WrapSummarized(nil, "sync failed", rules); err != nil {
+		t.Errorf("WrapSummarized(nil) = %v, want nil", err)
+	}
+}
+
+func TestWrapSummarizedFormat(t *testing.T) {
+	rules := []rxmerr.SummaryRule{{Label: "EOF", Plural: "EOF", Target: io.EOF}}
+	combined := rxmerr.Combine(io.EOF, &stackErr{"a"})
+	err := rxmerr.WrapSummarized(combined, "sync failed", rules)
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "%v", want: "sync failed: 1 EOF, 1 other"},
+		{format: "%s", want: "sync failed: 1 EOF, 1 other"},
+		{format: "%q", want: `"sync failed: 1 EOF, 1 other"`},
+		{format: "%+v", want: "sync failed: 1 EOF, 1 other\n2 errors occurred:\n  [0] EOF\n  [1] a\n      main.run\n      \tmain.go:12"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, err); got != tt.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+
+	if got, want := rxmerr.Format(err), rxmerr.Format(combined); got != want {
+		t.Errorf("Format() = %q, want the listing of the constituents %q", got, want)
+	}
+}
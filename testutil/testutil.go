@@ -0,0 +1,115 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package testutil provides Collector-aware assertions for table-driven
+// tests.
+//
+// Unlike a plain require.NoError(t, c.Err()), the helpers in this package
+// print every collected error on failure. They live in a separate package so
+// that the main rxmerr package does not depend on package testing.
+package testutil
+
+import (
+	"strings"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+// AssertEmpty fails the test if c has collected any errors.
+//
+// The failure message lists all collected errors, one bullet per error as
+// rendered by rxmerr.FormatBullet. AssertEmpty calls t.Fatalf, so the test
+// stops at the first failed assertion.
+func AssertEmpty(t testing.TB, c *rxmerr.Collector) {
+	t.Helper()
+	if c.HasError() {
+		t.Fatalf("expected no collected errors, got %d:\n%s", c.Len(), bullets(c))
+	}
+}
+
+// AssertLen fails the test if c has not collected exactly n errors.
+//
+// The failure message reports the actual count and lists all collected
+// errors, one bullet per error as rendered by rxmerr.FormatBullet. AssertLen
+// calls t.Fatalf, so the test stops at the first failed assertion.
+func AssertLen(t testing.TB, c *rxmerr.Collector, n int) {
+	t.Helper()
+	if got := c.Len(); got != n {
+		t.Fatalf("expected %d collected errors, got %d:\n%s", n, got, bullets(c))
+	}
+}
+
+// RequireNoErrors fails the test immediately if c has collected any errors.
+//
+// The failure message lists all collected errors, one bullet per error as
+// rendered by rxmerr.FormatBullet.
+func RequireNoErrors(t testing.TB, c *rxmerr.Collector) {
+	t.Helper()
+	if c.HasError() {
+		t.Fatalf("expected no collected errors, got %d:\n%s", c.Len(), bullets(c))
+	}
+}
+
+// AssertErrorCount reports a test failure if c has not collected exactly n
+// errors. The test continues after a failed assertion.
+//
+// The failure message lists all collected errors, one bullet per error as
+// rendered by rxmerr.FormatBullet.
+func AssertErrorCount(t testing.TB, c *rxmerr.Collector, n int) {
+	t.Helper()
+	if got := c.Len(); got != n {
+		t.Errorf("expected %d collected errors, got %d:\n%s", n, got, bullets(c))
+	}
+}
+
+// AssertContainsError reports a test failure if none of the errors collected
+// by c matches target according to errors.Is. The test continues after a
+// failed assertion.
+//
+// The failure message lists all collected errors, one bullet per error as
+// rendered by rxmerr.FormatBullet.
+func AssertContainsError(t testing.TB, c *rxmerr.Collector, target error) {
+	t.Helper()
+	if !c.Contains(target) {
+		t.Errorf("expected collected errors to contain %q, got %d:\n%s", target, c.Len(), bullets(c))
+	}
+}
+
+// AssertNoError reports a test failure if any error collected by c matches
+// target according to errors.Is. The test continues after a failed
+// assertion.
+//
+// The failure message lists all collected errors, one bullet per error as
+// rendered by rxmerr.FormatBullet.
+func AssertNoError(t testing.TB, c *rxmerr.Collector, target error) {
+	t.Helper()
+	if c.Contains(target) {
+		t.Errorf("expected collected errors not to contain %q, got %d:\n%s", target, c.Len(), bullets(c))
+	}
+}
+
+// bullets renders the errors collected by c as a bulleted list with
+// rxmerr.FormatBullet. The list is built from c.Errors() rather than c.Err(),
+// which is a single annotated error when c has a context (see
+// Collector.SetContext) and would collapse into one bullet.
+func bullets(c *rxmerr.Collector) string {
+	lines := make([]string, 0, c.Len())
+	for _, err := range c.Errors() {
+		lines = append(lines, rxmerr.FormatBullet(err))
+	}
+	return strings.Join(lines, "\n")
+}
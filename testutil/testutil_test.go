@@ -0,0 +1,176 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package testutil_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+	"dirpx.dev/rxmerr/testutil"
+)
+
+// fakeTB records failures instead of failing the enclosing test. Methods not
+// overridden panic through the nil embedded testing.TB.
+type fakeTB struct {
+	testing.TB
+	fatals []string
+	errors []string
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Fatalf(format string, args ...any) {
+	tb.fatals = append(tb.fatals, fmt.Sprintf(format, args...))
+}
+
+func (tb *fakeTB) Errorf(format string, args ...any) {
+	tb.errors = append(tb.errors, fmt.Sprintf(format, args...))
+}
+
+func collect(errs ...error) *rxmerr.Collector {
+	c := rxmerr.NewCollector()
+	c.Append(errs...)
+	return c
+}
+
+func TestAssertEmpty(t *testing.T) {
+	tb := &fakeTB{}
+	testutil.AssertEmpty(tb, collect(nil))
+	if len(tb.fatals) != 0 {
+		t.Errorf("AssertEmpty(empty) failed: %q", tb.fatals)
+	}
+
+	tb = &fakeTB{}
+	testutil.AssertEmpty(tb, collect(errors.New("a"), errors.New("b")))
+	if len(tb.fatals) != 1 {
+		t.Fatalf("AssertEmpty(non-empty) fatals = %q, want one", tb.fatals)
+	}
+	if want := "expected no collected errors, got 2:\n- a\n- b"; tb.fatals[0] != want {
+		t.Errorf("failure message = %q, want %q", tb.fatals[0], want)
+	}
+}
+
+func TestAssertLen(t *testing.T) {
+	c := collect(errors.New("a"), errors.New("b"))
+
+	tests := []struct {
+		n        int
+		wantFail bool
+	}{
+		{n: 2},
+		{n: 0, wantFail: true},
+		{n: 3, wantFail: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.n), func(t *testing.T) {
+			tb := &fakeTB{}
+			testutil.AssertLen(tb, c, tt.n)
+			if got := len(tb.fatals) > 0; got != tt.wantFail {
+				t.Fatalf("Fatalf called = %v, want %v", got, tt.wantFail)
+			}
+			want := fmt.Sprintf("expected %d collected errors, got 2:\n- a\n- b", tt.n)
+			if tt.wantFail && tb.fatals[0] != want {
+				t.Errorf("failure message = %q, want %q", tb.fatals[0], want)
+			}
+		})
+	}
+}
+
+func TestRequireNoErrors(t *testing.T) {
+	tb := &fakeTB{}
+	testutil.RequireNoErrors(tb, collect(nil))
+	if len(tb.fatals) != 0 {
+		t.Errorf("RequireNoErrors(empty) failed: %q", tb.fatals)
+	}
+
+	tb = &fakeTB{}
+	testutil.RequireNoErrors(tb, collect(errors.New("a"), errors.New("b")))
+	want := "expected no collected errors, got 2:\n- a\n- b"
+	if len(tb.fatals) != 1 || tb.fatals[0] != want {
+		t.Errorf("RequireNoErrors(non-empty) fatals = %q, want [%q]", tb.fatals, want)
+	}
+}
+
+func TestAssertErrorCount(t *testing.T) {
+	c := collect(errors.New("a"), errors.New("b"))
+
+	tb := &fakeTB{}
+	testutil.AssertErrorCount(tb, c, 2)
+	if len(tb.errors) != 0 {
+		t.Errorf("AssertErrorCount(2) failed: %q", tb.errors)
+	}
+
+	tb = &fakeTB{}
+	testutil.AssertErrorCount(tb, c, 1)
+	want := "expected 1 collected errors, got 2:\n- a\n- b"
+	if len(tb.errors) != 1 || tb.errors[0] != want {
+		t.Errorf("AssertErrorCount(1) errors = %q, want [%q]", tb.errors, want)
+	}
+	if len(tb.fatals) != 0 {
+		t.Errorf("AssertErrorCount called Fatalf: %q", tb.fatals)
+	}
+}
+
+func TestAssertContainsError(t *testing.T) {
+	c := collect(fmt.Errorf("read: %w", io.EOF))
+
+	tb := &fakeTB{}
+	testutil.AssertContainsError(tb, c, io.EOF)
+	if len(tb.errors) != 0 {
+		t.Errorf("AssertContainsError(io.EOF) failed: %q", tb.errors)
+	}
+
+	tb = &fakeTB{}
+	testutil.AssertContainsError(tb, c, io.ErrUnexpectedEOF)
+	want := `expected collected errors to contain "unexpected EOF", got 1:` + "\n- read: EOF"
+	if len(tb.errors) != 1 || tb.errors[0] != want {
+		t.Errorf("AssertContainsError(io.ErrUnexpectedEOF) errors = %q, want [%q]", tb.errors, want)
+	}
+}
+
+func TestAssertNoError(t *testing.T) {
+	c := collect(fmt.Errorf("read: %w", io.EOF))
+
+	tb := &fakeTB{}
+	testutil.AssertNoError(tb, c, io.ErrUnexpectedEOF)
+	if len(tb.errors) != 0 {
+		t.Errorf("AssertNoError(io.ErrUnexpectedEOF) failed: %q", tb.errors)
+	}
+
+	tb = &fakeTB{}
+	testutil.AssertNoError(tb, c, io.EOF)
+	want := `expected collected errors not to contain "EOF", got 1:` + "\n- read: EOF"
+	if len(tb.errors) != 1 || tb.errors[0] != want {
+		t.Errorf("AssertNoError(io.EOF) errors = %q, want [%q]", tb.errors, want)
+	}
+}
+
+func TestFailureListIgnoresContext(t *testing.T) {
+	c := collect(errors.New("a"), errors.New("b"))
+	c.SetContext("sync routes")
+
+	tb := &fakeTB{}
+	testutil.AssertErrorCount(tb, c, 0)
+	want := "expected 0 collected errors, got 2:\n- a\n- b"
+	if len(tb.errors) != 1 || tb.errors[0] != want {
+		t.Errorf("errors = %q, want [%q]", tb.errors, want)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import "errors"
+
+// Translation is a rule of a Translate table.
+//
+// A rule matches a constituent if Match reports true for it or, if Match is
+// nil, if errors.Is(constituent, Target) holds. A matching constituent is
+// replaced with the result of Replace.
+type Translation struct {
+	// Target is the error matched with errors.Is when Match is nil.
+	Target error
+
+	// Match reports whether the rule applies to a constituent. If set, it
+	// takes precedence over Target.
+	Match func(error) bool
+
+	// Replace returns the translated error. Returning nil, or leaving Replace
+	// nil, drops the constituent.
+	Replace func(error) error
+}
+
+// Translate rewrites the constituents of err according to a table of rules,
+// typically to translate storage-layer errors into domain errors at a
+// repository boundary:
+//
+//	var storageErrors = []rxmerr.Translation{
+//	    {Target: sql.ErrNoRows, Replace: func(error) error { return ErrNotFound }},
+//	    {Match: isDriverTimeout, Replace: func(err error) error {
+//	        return fmt.Errorf("%w: %w", ErrUnavailable, err)
+//	    }},
+//	}
+//
+//	return rxmerr.Translate(err, storageErrors)
+//
+// Every constituent of err (see Errors) is checked against the rules in
+// order, and the first matching rule is applied; later rules are not
+// consulted. Constituents that no rule matches are passed through unchanged.
+// The results are combined again in the original order. If err is nil, or
+// every constituent is dropped, Translate returns nil.
+func Translate(err error, table []Translation) error {
+	errs := Errors(err)
+	for i, e := range errs {
+		for _, t := range table {
+			if !t.matches(e) {
+				continue
+			}
+			if t.Replace == nil {
+				errs[i] = nil
+			} else {
+				errs[i] = t.Replace(e)
+			}
+			break
+		}
+	}
+	return Combine(errs...)
+}
+
+// matches reports whether the rule applies to err.
+func (t Translation) matches(err error) bool {
+	if t.Match != nil {
+		return t.Match(err)
+	}
+	return t.Target != nil && errors.Is(err, t.Target)
+}
@@ -0,0 +1,135 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+var (
+	errNoRecord    = errors.New("record not found")
+	errUnavailable = errors.New("storage unavailable")
+	errDriver      = errors.New("driver: i/o timeout")
+)
+
+func TestTranslate(t *testing.T) {
+	notFound := func(error) error { return errNoRecord }
+	unavailable := func(err error) error { return fmt.Errorf("%w: %w", errUnavailable, err) }
+	isTimeout := func(err error) bool { return strings.Contains(err.Error(), "timeout") }
+
+	tests := []struct {
+		name  string
+		err   error
+		table []rxmerr.Translation
+		want  []string
+	}{
+		{
+			name:  "nil",
+			table: []rxmerr.Translation{{Target: sql.ErrNoRows, Replace: notFound}},
+		},
+		{
+			name:  "target",
+			err:   rxmerr.Combine(sql.ErrNoRows, errDriver),
+			table: []rxmerr.Translation{{Target: sql.ErrNoRows, Replace: notFound}},
+			want:  []string{"record not found", "driver: i/o timeout"},
+		},
+		{
+			name:  "wrapped target",
+			err:   fmt.Errorf("get user: %w", sql.ErrNoRows),
+			table: []rxmerr.Translation{{Target: sql.ErrNoRows, Replace: notFound}},
+			want:  []string{"record not found"},
+		},
+		{
+			name:  "match",
+			err:   rxmerr.Combine(errDriver, sql.ErrNoRows),
+			table: []rxmerr.Translation{{Match: isTimeout, Replace: unavailable}},
+			want:  []string{"storage unavailable: driver: i/o timeout", "sql: no rows in result set"},
+		},
+		{
+			name: "first match wins",
+			err:  rxmerr.Combine(errDriver, sql.ErrNoRows),
+			table: []rxmerr.Translation{
+				{Match: isTimeout, Replace: unavailable},
+				{Target: errDriver, Replace: notFound},
+			},
+			want: []string{"storage unavailable: driver: i/o timeout", "sql: no rows in result set"},
+		},
+		{
+			name: "match takes precedence over target",
+			err:  sql.ErrNoRows,
+			table: []rxmerr.Translation{
+				{Target: sql.ErrNoRows, Match: isTimeout, Replace: notFound},
+			},
+			want: []string{"sql: no rows in result set"},
+		},
+		{
+			name:  "no match",
+			err:   rxmerr.Combine(errDriver, errNoRecord),
+			table: []rxmerr.Translation{{Target: sql.ErrNoRows, Replace: notFound}},
+			want:  []string{"driver: i/o timeout", "record not found"},
+		},
+		{
+			name:  "drop with nil result",
+			err:   rxmerr.Combine(sql.ErrNoRows, errDriver),
+			table: []rxmerr.Translation{{Target: sql.ErrNoRows, Replace: func(error) error { return nil }}},
+			want:  []string{"driver: i/o timeout"},
+		},
+		{
+			name:  "drop with nil Replace",
+			err:   rxmerr.Combine(errDriver, sql.ErrNoRows),
+			table: []rxmerr.Translation{{Target: sql.ErrNoRows}},
+			want:  []string{"driver: i/o timeout"},
+		},
+		{
+			name:  "drop all",
+			err:   rxmerr.Combine(sql.ErrNoRows, sql.ErrNoRows),
+			table: []rxmerr.Translation{{Target: sql.ErrNoRows}},
+		},
+		{
+			name:  "empty table",
+			err:   rxmerr.Combine(sql.ErrNoRows, errDriver),
+			table: nil,
+			want:  []string{"sql: no rows in result set", "driver: i/o timeout"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rxmerr.Translate(tt.err, tt.table)
+			if got := rxmerr.Messages(err); !slices.Equal(got, tt.want) {
+				t.Errorf("Messages(Translate()) = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslateKeepsChains(t *testing.T) {
+	err := rxmerr.Translate(rxmerr.Combine(errDriver, sql.ErrNoRows), []rxmerr.Translation{
+		{Target: errDriver, Replace: func(err error) error { return fmt.Errorf("%w: %w", errUnavailable, err) }},
+	})
+	for _, target := range []error{errUnavailable, errDriver, sql.ErrNoRows} {
+		if !errors.Is(err, target) {
+			t.Errorf("errors.Is(Translate(), %v) = false", target)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// AppendWithTTL appends err with a time to live: once ttl has elapsed, err
+// is removed by the next call of Expire.
+//
+// The expiry is computed from the collector's clock (see WithClock) at
+// append time. This keeps the errors of a long-lived collector, for example
+// one reporting the health of a service, limited to a recent window:
+//
+//	c.AppendWithTTL(err, 5*time.Minute)
+//	...
+//	c.Expire()
+//	healthy := !c.HasError()
+//
+// Errors appended with other Append methods never expire. Apart from its
+// expiry, err is collected like with Append; its message is unchanged and it
+// remains reachable via errors.Is and errors.As. A nil err is ignored.
+func (c *Collector) AppendWithTTL(err error, ttl time.Duration) {
+	if err == nil {
+		return
+	}
+	c.Append(expiringError{err: err, expires: c.timeFrom(nil).Add(ttl)})
+}
+
+// Expire removes the errors appended with AppendWithTTL whose time to live
+// has elapsed according to the collector's clock, and returns the number of
+// errors removed. Len decreases by the same number.
+//
+// An entry merged by WithCoalesce or CollapseConsecutive expires with the
+// first error of its run, and counts as every append of the run. Like
+// TruncateAt, Expire rewrites the stored errors; error values previously
+// returned by Err are not affected.
+func (c *Collector) Expire() int {
+	errs := c.storedErrors()
+	now := c.timeFrom(nil)
+	kept := errs[:0]
+	removed := 0
+	for _, err := range errs {
+		if expires, ok := expiryOf(err); ok && !now.Before(expires) {
+			removed += appendsIn(err)
+			continue
+		}
+		kept = append(kept, err)
+	}
+	if removed > 0 {
+		c.replaceAll(kept)
+		c.count = max(c.count-removed, 0)
+	}
+	return removed
+}
+
+// NextExpiry returns the earliest expiry of the collected errors appended
+// with AppendWithTTL, or the zero time if there are none.
+func (c *Collector) NextExpiry() time.Time {
+	var next time.Time
+	for _, err := range c.storedErrors() {
+		if expires, ok := expiryOf(err); ok && (next.IsZero() || expires.Before(next)) {
+			next = expires
+		}
+	}
+	return next
+}
+
+// StartAutoExpire makes the collector expire errors periodically, see
+// Expire, until StopAutoExpire is called.
+//
+// Because Collector is not safe for concurrent use, the background goroutine
+// started by StartAutoExpire does not modify the collector itself. Every
+// interval, it marks expiry as due, and the collector calls Expire on the
+// goroutine that uses it, at the start of its next Append, Err, Errors, Len,
+// Stored, or HasError call. A collector that is not used in the meantime is
+// therefore not pruned, but never reports expired errors either.
+//
+// Calling StartAutoExpire again replaces the previous interval. It panics if
+// interval is not positive. Auto-expiry survives Reset.
+func (c *Collector) StartAutoExpire(interval time.Duration) {
+	if interval <= 0 {
+		panic(fmt.Sprintf("rxmerr: non-positive auto-expire interval %v", interval))
+	}
+	c.StopAutoExpire()
+
+	ae := &autoExpiry{stop: make(chan struct{})}
+	c.autoExpiry = ae
+	go ae.run(interval)
+}
+
+// StopAutoExpire stops the background goroutine started by StartAutoExpire.
+// It does nothing if auto-expiry is not running.
+func (c *Collector) StopAutoExpire() {
+	if c.autoExpiry != nil {
+		close(c.autoExpiry.stop)
+		c.autoExpiry = nil
+	}
+}
+
+// autoExpiry is the state shared with the goroutine started by
+// StartAutoExpire.
+type autoExpiry struct {
+	due  atomic.Bool   // set by the goroutine, cleared by expireIfDue
+	stop chan struct{} // closed by StopAutoExpire
+}
+
+// run marks expiry as due every interval until stop is closed.
+func (ae *autoExpiry) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			ae.due.Store(true)
+		case <-ae.stop:
+			return
+		}
+	}
+}
+
+// expireIfDue calls Expire if auto-expiry marked it as due.
+func (c *Collector) expireIfDue() {
+	if c.autoExpiry != nil && c.autoExpiry.due.CompareAndSwap(true, false) {
+		c.Expire()
+	}
+}
+
+// expiryOf returns the expiry of err if it was appended with AppendWithTTL.
+func expiryOf(err error) (time.Time, bool) {
+	var e expiringError
+	if errors.As(err, &e) {
+		return e.expires, true
+	}
+	return time.Time{}, false
+}
+
+// expiringError carries the expiry of an error appended with AppendWithTTL.
+type expiringError struct {
+	err     error
+	expires time.Time
+}
+
+func (e expiringError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the expiring error.
+func (e expiringError) Unwrap() error {
+	return e.err
+}
+
+// Format implements fmt.Formatter: %+v formats the expiring error with %+v,
+// %q quotes Error(), and other verbs write Error().
+func (e expiringError) Format(f fmt.State, verb rune) {
+	formatWrapper(f, verb, e, "", e.err)
+}
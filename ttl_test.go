@@ -0,0 +1,162 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestExpire(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.WithClock(fakeClock(&now)))
+	c.AppendWithTTL(errors.New("short"), time.Minute)
+	c.Append(io.EOF)
+	c.AppendWithTTL(errors.New("long"), time.Hour)
+	c.AppendWithTTL(nil, time.Minute)
+
+	if got, want := c.NextExpiry(), now.Add(time.Minute); !got.Equal(want) {
+		t.Errorf("NextExpiry() = %v, want %v", got, want)
+	}
+
+	steps := []struct {
+		advance time.Duration
+		removed int
+		want    []string
+	}{
+		{30 * time.Second, 0, []string{"short", "EOF", "long"}},
+		{30 * time.Second, 1, []string{"EOF", "long"}},
+		{time.Hour, 1, []string{"EOF"}},
+		{time.Hour, 0, []string{"EOF"}},
+	}
+	for i, s := range steps {
+		now = now.Add(s.advance)
+		if got := c.Expire(); got != s.removed {
+			t.Errorf("step %d: Expire() = %d, want %d", i, got, s.removed)
+		}
+		if got := c.Messages(); !slices.Equal(got, s.want) {
+			t.Errorf("step %d: Messages() = %q, want %q", i, got, s.want)
+		}
+		if got := c.Len(); got != len(s.want) {
+			t.Errorf("step %d: Len() = %d, want %d", i, got, len(s.want))
+		}
+	}
+	if got := c.NextExpiry(); !got.IsZero() {
+		t.Errorf("NextExpiry() without expiring errors = %v, want the zero time", got)
+	}
+}
+
+func TestExpireKeepsChains(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.WithClock(fakeClock(&now)))
+	c.AppendWithTTL(io.EOF, time.Minute)
+
+	err := c.Err()
+	if got := err.Error(); got != "EOF" {
+		t.Errorf("Err() = %q, want %q", got, "EOF")
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("errors.Is(Err(), io.EOF) = false")
+	}
+
+	now = now.Add(time.Minute)
+	c.Expire()
+	if !errors.Is(err, io.EOF) {
+		t.Error("Expire() modified an aggregate previously returned by Err")
+	}
+	if got := c.Err(); got != nil {
+		t.Errorf("Err() after Expire = %v, want nil", got)
+	}
+}
+
+func TestExpireCoalesced(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.WithClock(fakeClock(&now)), rxmerr.CollapseConsecutive())
+	for range 3 {
+		c.AppendWithTTL(io.EOF, time.Minute)
+	}
+	c.Append(io.ErrClosedPipe)
+
+	if got := c.Len(); got != 4 {
+		t.Fatalf("Len() = %d, want 4", got)
+	}
+	now = now.Add(time.Minute)
+	if got := c.Expire(); got != 3 {
+		t.Errorf("Expire() = %d, want 3 for a run of three appends", got)
+	}
+	if got := c.Len(); got != 1 {
+		t.Errorf("Len() after Expire = %d, want 1", got)
+	}
+	if got, want := c.Messages(), []string{"io: read/write on closed pipe"}; !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+}
+
+func TestExpireInterned(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	c := rxmerr.NewCollector(rxmerr.WithClock(fakeClock(&now)))
+	for range 3 {
+		c.Append(io.EOF)
+	}
+	c.AppendWithTTL(io.ErrClosedPipe, time.Minute)
+	c.Append(io.EOF)
+
+	now = now.Add(time.Minute)
+	if got := c.Expire(); got != 1 {
+		t.Errorf("Expire() = %d, want 1", got)
+	}
+	if got, want := c.Messages(), []string{"EOF", "EOF", "EOF", "EOF"}; !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+	if got := c.Len(); got != 4 {
+		t.Errorf("Len() = %d, want 4", got)
+	}
+}
+
+func TestStartAutoExpire(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.StartAutoExpire(time.Millisecond)
+	defer c.StopAutoExpire()
+
+	c.AppendWithTTL(io.EOF, time.Millisecond)
+	c.Append(io.ErrClosedPipe)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for c.Len() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Len() = %d after 5s, want the expired error pruned", c.Len())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got, want := c.Messages(), []string{"io: read/write on closed pipe"}; !slices.Equal(got, want) {
+		t.Errorf("Messages() = %q, want %q", got, want)
+	}
+}
+
+func TestStartAutoExpirePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("StartAutoExpire(0) did not panic")
+		}
+	}()
+	rxmerr.NewCollector().StartAutoExpire(0)
+}
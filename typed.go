@@ -0,0 +1,128 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import "errors"
+
+// MapMultiError returns the constituents of err whose dynamic type is E.
+//
+// Constituents are obtained via Errors and type-asserted to E; those for
+// which the assertion succeeds are returned in order. This replaces the usual
+// loop over Errors with a type assertion:
+//
+//	dbErrs := rxmerr.MapMultiError[*DBError](err)
+//
+// The assertion is applied to each constituent itself: an E wrapped inside a
+// constituent (for example with fmt.Errorf and %w) is not extracted. Use
+// errors.As on individual constituents when wrapped values must be found.
+//
+// If err is nil, MapMultiError returns nil. If no constituent is an E, it
+// returns an empty, non-nil slice.
+func MapMultiError[E error](err error) []E {
+	if err == nil {
+		return nil
+	}
+
+	out := []E{}
+	for _, e := range Errors(err) {
+		if v, ok := e.(E); ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// TypedCollector accumulates errors of a single concrete type T, such as a
+// domain-specific *ValidationIssue, without losing the type.
+//
+// Programmatic consumers read the items with their concrete type through
+// Items, while Err provides the aggregate as an error for return values:
+//
+//	tc := rxmerr.NewTypedCollector[*ValidationIssue]()
+//	tc.Append(&ValidationIssue{Field: "name", Problem: "required"})
+//	tc.AppendErr(validateEmail(req.Email)) // accepted if it wraps a *ValidationIssue
+//	for _, issue := range tc.Items() {
+//	    resp.AddFieldError(issue.Field, issue.Problem)
+//	}
+//	return tc.Err()
+//
+// Like Collector, TypedCollector is NOT safe for concurrent use. The zero
+// value is an empty collector ready for use.
+type TypedCollector[T error] struct {
+	items []T
+}
+
+// NewTypedCollector creates a new, empty TypedCollector.
+func NewTypedCollector[T error]() *TypedCollector[T] {
+	return &TypedCollector[T]{}
+}
+
+// Append adds item to the collector. item is stored as-is; callers MUST NOT
+// append a nil item.
+func (tc *TypedCollector[T]) Append(item T) {
+	tc.items = append(tc.items, item)
+}
+
+// AppendErr adds err to the collector if errors.As finds a T in its chain,
+// and reports whether it did. The T that was found is stored, not err itself,
+// so wrapping context added around it is not kept.
+//
+// If err is nil, or its chain contains no T, AppendErr does nothing and
+// returns false; callers feeding the collector from mixed sources SHOULD
+// handle such errors separately.
+func (tc *TypedCollector[T]) AppendErr(err error) bool {
+	var item T
+	if err == nil || !errors.As(err, &item) {
+		return false
+	}
+	tc.items = append(tc.items, item)
+	return true
+}
+
+// Items returns the collected items in the order in which they were
+// appended, or nil if none were collected. The returned slice is a copy.
+func (tc *TypedCollector[T]) Items() []T {
+	if len(tc.items) == 0 {
+		return nil
+	}
+	return append([]T(nil), tc.items...)
+}
+
+// Len returns the number of collected items.
+func (tc *TypedCollector[T]) Len() int {
+	return len(tc.items)
+}
+
+// HasError reports whether at least one item has been collected.
+func (tc *TypedCollector[T]) HasError() bool {
+	return len(tc.items) > 0
+}
+
+// Err returns the aggregate of the collected items, built with Combine, or nil
+// if none were collected.
+func (tc *TypedCollector[T]) Err() error {
+	errs := make([]error, len(tc.items))
+	for i, item := range tc.items {
+		errs[i] = item
+	}
+	return Combine(errs...)
+}
+
+// Reset removes all collected items.
+func (tc *TypedCollector[T]) Reset() {
+	tc.items = nil
+}
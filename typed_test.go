@@ -0,0 +1,118 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+// validationIssue is a domain-specific error type.
+type validationIssue struct {
+	field, problem string
+}
+
+func (e *validationIssue) Error() string {
+	return e.field + ": " + e.problem
+}
+
+func TestTypedCollector(t *testing.T) {
+	name := &validationIssue{"name", "required"}
+	email := &validationIssue{"email", "invalid"}
+
+	tc := rxmerr.NewTypedCollector[*validationIssue]()
+	if tc.HasError() || tc.Items() != nil || tc.Err() != nil {
+		t.Fatalf("new collector: HasError() = %v, Items() = %v, Err() = %v", tc.HasError(), tc.Items(), tc.Err())
+	}
+
+	tc.Append(name)
+	tc.Append(email)
+
+	items := tc.Items()
+	if !slices.Equal(items, []*validationIssue{name, email}) {
+		t.Errorf("Items() = %v, want [name email]", items)
+	}
+	items[0] = nil
+	if got := tc.Items()[0]; got != name {
+		t.Errorf("Items()[0] after modifying a previous result = %v, want %v", got, name)
+	}
+	if got := tc.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+
+	err := tc.Err()
+	if got, want := err.Error(), "name: required; email: invalid"; got != want {
+		t.Errorf("Err() = %q, want %q", got, want)
+	}
+	if got := rxmerr.MapMultiError[*validationIssue](err); !slices.Equal(got, []*validationIssue{name, email}) {
+		t.Errorf("MapMultiError(Err()) = %v, want [name email]", got)
+	}
+
+	tc.Reset()
+	if tc.HasError() || tc.Err() != nil {
+		t.Errorf("after Reset: HasError() = %v, Err() = %v", tc.HasError(), tc.Err())
+	}
+}
+
+func TestTypedCollectorAppendErr(t *testing.T) {
+	issue := &validationIssue{"email", "invalid"}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"direct", issue, true},
+		{"wrapped", fmt.Errorf("validate request: %w", issue), true},
+		{"in aggregate", rxmerr.Combine(io.EOF, issue), true},
+		{"other type", io.EOF, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tc rxmerr.TypedCollector[*validationIssue]
+			if got := tc.AppendErr(tt.err); got != tt.want {
+				t.Fatalf("AppendErr() = %v, want %v", got, tt.want)
+			}
+			var want []*validationIssue
+			if tt.want {
+				want = []*validationIssue{issue}
+			}
+			if got := tc.Items(); !slices.Equal(got, want) {
+				t.Errorf("Items() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestTypedCollectorAppendErrDropsWrapping(t *testing.T) {
+	issue := &validationIssue{"email", "invalid"}
+	tc := rxmerr.NewTypedCollector[*validationIssue]()
+	tc.AppendErr(fmt.Errorf("validate request: %w", issue))
+
+	if got, want := tc.Err().Error(), "email: invalid"; got != want {
+		t.Errorf("Err() = %q, want %q", got, want)
+	}
+	if !errors.Is(tc.Err(), issue) {
+		t.Error("errors.Is(Err(), issue) = false")
+	}
+}
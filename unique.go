@@ -0,0 +1,74 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import "errors"
+
+// AppendIfNew appends err unless an error matching it is already collected.
+//
+// An error is considered present if errors.Is(existing, err) reports true
+// for some collected error, which means that it was appended before, either
+// as is or wrapped, or that a collected error matches it through a custom Is
+// method. Deduplicating by identity rather than by message correctly handles
+// sentinels and wrapped errors:
+//
+//	c.AppendIfNew(fmt.Errorf("read a: %w", io.ErrUnexpectedEOF))
+//	c.AppendIfNew(io.ErrUnexpectedEOF) // dropped: already reachable
+//
+// The check is made against the collected errors before err passes through
+// the collector's options (see Option). It is linear in the size of the
+// collection. A nil err is ignored, as with Append. AppendIfNew reports
+// whether err was passed on to Append.
+func (c *Collector) AppendIfNew(err error) bool {
+	if err == nil || c.Contains(err) {
+		return false
+	}
+	c.Append(err)
+	return true
+}
+
+// AppendIfNewFunc calls fn and passes its returned error to AppendIfNew.
+func (c *Collector) AppendIfNewFunc(fn func() error) bool {
+	return c.AppendIfNew(fn())
+}
+
+// UniqueLen returns the number of distinct collected errors, where an error
+// is not distinct if errors.Is matches it against an earlier collected error,
+// as for AppendIfNew.
+//
+// Unlike Len, which counts every append, UniqueLen counts each error that is
+// reachable from several collected errors only once. It runs in time
+// quadratic in the size of the collection.
+func (c *Collector) UniqueLen() int {
+	var unique []error
+	for _, err := range c.Errors() {
+		if !containsIs(unique, err) {
+			unique = append(unique, err)
+		}
+	}
+	return len(unique)
+}
+
+// containsIs reports whether errors.Is(e, target) holds for some e in errs.
+func containsIs(errs []error, target error) bool {
+	for _, e := range errs {
+		if errors.Is(e, target) {
+			return true
+		}
+	}
+	return false
+}
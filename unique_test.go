@@ -0,0 +1,89 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestAppendIfNew(t *testing.T) {
+	errA := errors.New("a")
+	c := rxmerr.NewCollector()
+
+	steps := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{fmt.Errorf("read a: %w", io.ErrUnexpectedEOF), true},
+		{io.ErrUnexpectedEOF, false},
+		{errA, true},
+		{errA, false},
+		{errors.New("a"), true}, // equal message, different identity
+		{fmt.Errorf("again: %w", errA), true},
+	}
+	for i, s := range steps {
+		if got := c.AppendIfNew(s.err); got != s.want {
+			t.Errorf("step %d: AppendIfNew(%v) = %v, want %v", i, s.err, got, s.want)
+		}
+	}
+	if got := c.Len(); got != 4 {
+		t.Errorf("Len() = %d, want 4", got)
+	}
+	if got := c.UniqueLen(); got != 4 {
+		t.Errorf("UniqueLen() = %d, want 4", got)
+	}
+}
+
+func TestAppendIfNewFunc(t *testing.T) {
+	c := rxmerr.NewCollector()
+	closeFn := func() error { return io.ErrClosedPipe }
+
+	if !c.AppendIfNewFunc(closeFn) {
+		t.Error("first AppendIfNewFunc() = false, want true")
+	}
+	if c.AppendIfNewFunc(closeFn) {
+		t.Error("second AppendIfNewFunc() = true, want false")
+	}
+	if c.AppendIfNewFunc(func() error { return nil }) {
+		t.Error("AppendIfNewFunc() with a nil result = true, want false")
+	}
+	if got := c.Len(); got != 1 {
+		t.Errorf("Len() = %d, want 1", got)
+	}
+}
+
+func TestUniqueLen(t *testing.T) {
+	c := rxmerr.NewCollector()
+	if got := c.UniqueLen(); got != 0 {
+		t.Errorf("UniqueLen() on empty collector = %d, want 0", got)
+	}
+
+	c.Append(io.EOF, io.EOF, fmt.Errorf("wrapped: %w", io.EOF), io.ErrClosedPipe)
+	if got := c.Len(); got != 4 {
+		t.Errorf("Len() = %d, want 4", got)
+	}
+	// The wrapped EOF is distinct: errors.Is(io.EOF, wrapped) is false.
+	if got := c.UniqueLen(); got != 3 {
+		t.Errorf("UniqueLen() = %d, want 3", got)
+	}
+}
@@ -0,0 +1,228 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CombineVerbose merges errs like Combine, but returns an aggregate whose
+// formatting distinguishes compact and verbose output.
+//
+// The returned error implements fmt.Formatter:
+//
+//   - %v and %s print the compact, single-line form (the same as Error());
+//   - %q prints the compact form as a quoted string;
+//   - %+v prints a multi-line listing with one numbered constituent per line,
+//     each formatted with %+v so that constituents carrying extra detail
+//     (such as stack traces) can print it.
+//
+// For example:
+//
+//	err := rxmerr.CombineVerbose(errA, errB)
+//	fmt.Printf("%v\n", err)  // a; b
+//	fmt.Printf("%+v\n", err) // 2 errors occurred:
+//	                         //   [0] a
+//	                         //   [1] b
+//
+// If all errs are nil, CombineVerbose returns nil. The constituents of the
+// returned error are the same as those of Combine(errs...) and are available
+// via Errors, errors.Is, and errors.As.
+func CombineVerbose(errs ...error) error {
+	err := Combine(errs...)
+	if err == nil {
+		return nil
+	}
+	return &verboseError{err: err}
+}
+
+// verboseError decorates an aggregate with a fmt.Formatter implementation.
+type verboseError struct {
+	err error
+}
+
+func (e *verboseError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the constituents of the decorated aggregate.
+func (e *verboseError) Unwrap() []error {
+	return Errors(e.err)
+}
+
+// Format implements fmt.Formatter.
+func (e *verboseError) Format(f fmt.State, verb rune) {
+	formatAggregate(f, verb, e, e.Unwrap())
+}
+
+// formatAggregate implements fmt.Formatter for err, an aggregate of errs,
+// the way CombineVerbose documents it: %+v writes the listing of
+// writeVerbose, which formats each constituent with %+v in turn, %q quotes
+// Error(), and every other verb writes Error().
+//
+// All aggregate and wrapper types of this package format this way (wrappers
+// with formatWrapper or formatSummary), so that details printed by the %+v
+// output of leaf errors, such as the stack traces of github.com/pkg/errors,
+// are not lost when the leaves are aggregated.
+func formatAggregate(f fmt.State, verb rune, err error, errs []error) {
+	if verb == 'v' && f.Flag('+') {
+		writeVerbose(f, errs)
+		return
+	}
+	formatCompact(f, verb, err)
+}
+
+// formatWrapper implements fmt.Formatter for err, which wraps inner and whose
+// Error() is prefix followed by inner.Error(): %+v writes prefix followed by
+// the %+v output of inner, and other verbs behave as for formatAggregate.
+func formatWrapper(f fmt.State, verb rune, err error, prefix string, inner error) {
+	if verb == 'v' && f.Flag('+') {
+		io.WriteString(f, prefix)
+		fmt.Fprintf(f, "%+v", inner)
+		return
+	}
+	formatCompact(f, verb, err)
+}
+
+// formatSummary implements fmt.Formatter for err, which wraps inner and whose
+// Error() restates inner.Error() in a different form, such as shortened or
+// annotated with a count: %+v writes Error() followed by what the %+v output
+// of inner adds after its message, such as a stack trace, and other verbs
+// behave as for formatAggregate. If the %+v output of inner does not start
+// with its message, %+v writes Error() alone.
+func formatSummary(f fmt.State, verb rune, err error, inner error) {
+	if verb == 'v' && f.Flag('+') {
+		io.WriteString(f, err.Error())
+		detail, ok := strings.CutPrefix(fmt.Sprintf("%+v", inner), inner.Error())
+		if ok {
+			io.WriteString(f, detail)
+		}
+		return
+	}
+	formatCompact(f, verb, err)
+}
+
+// formatCompact writes the compact form of err: quoted for %q, as is
+// otherwise.
+func formatCompact(f fmt.State, verb rune, err error) {
+	if verb == 'q' {
+		fmt.Fprintf(f, "%q", err.Error())
+		return
+	}
+	io.WriteString(f, err.Error())
+}
+
+// annotatedError is an error wrapped with a message, rendered as
+// "msg: <err>" like fmt.Errorf("%s: %w", msg, err), but formatting err with
+// %+v under %+v.
+type annotatedError struct {
+	msg string
+	err error
+}
+
+func (e *annotatedError) Error() string {
+	return e.msg + ": " + e.err.Error()
+}
+
+// Unwrap returns the annotated error.
+func (e *annotatedError) Unwrap() error {
+	return e.err
+}
+
+// Format implements fmt.Formatter, see formatWrapper.
+func (e *annotatedError) Format(f fmt.State, verb rune) {
+	formatWrapper(f, verb, e, e.msg+": ", e.err)
+}
+
+// writeVerbose writes the multi-line listing of errs used by %+v. Multi-line
+// constituent output is indented so that it stays attached to its entry.
+func writeVerbose(w io.Writer, errs []error) {
+	if len(errs) == 1 {
+		io.WriteString(w, "1 error occurred:")
+	} else {
+		fmt.Fprintf(w, "%d errors occurred:", len(errs))
+	}
+	for i, err := range errs {
+		prefix := fmt.Sprintf("  [%d] ", i)
+		indent := strings.Repeat(" ", len(prefix))
+		detail := strings.ReplaceAll(fmt.Sprintf("%+v", err), "\n", "\n"+indent)
+		io.WriteString(w, "\n"+prefix+detail)
+	}
+}
+
+// CombineDebug merges errs like Combine, but returns an aggregate that
+// implements fmt.GoStringer for readable debugging dumps.
+//
+// Formatting the result with %#v lists each constituent with its dynamic type
+// and message instead of the aggregate's internal structure:
+//
+//	fmt.Printf("%#v\n", rxmerr.CombineDebug(errA, errB))
+//	// rxmerr.multi{errors: [*errors.errorString("a"), *fmt.wrapError("b: c")]}
+//
+// %+v and %q behave as for CombineVerbose; all other verbs and Error()
+// behave as for Combine. If all errs are nil,
+// CombineDebug returns nil. The constituents of the returned error are
+// available via Errors, errors.Is, and errors.As.
+func CombineDebug(errs ...error) error {
+	err := Combine(errs...)
+	if err == nil {
+		return nil
+	}
+	return &debugError{err: err}
+}
+
+// debugError decorates an aggregate with a fmt.GoStringer implementation.
+//
+// Its fmt.Formatter implementation takes precedence over GoString, so Format
+// dispatches the %#v verb to GoString explicitly.
+type debugError struct {
+	err error
+}
+
+func (e *debugError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the constituents of the decorated aggregate.
+func (e *debugError) Unwrap() []error {
+	return Errors(e.err)
+}
+
+// Format implements fmt.Formatter, see formatAggregate.
+func (e *debugError) Format(f fmt.State, verb rune) {
+	if verb == 'v' && f.Flag('#') {
+		io.WriteString(f, e.GoString())
+		return
+	}
+	formatAggregate(f, verb, e, e.Unwrap())
+}
+
+// GoString implements fmt.GoStringer.
+func (e *debugError) GoString() string {
+	var b strings.Builder
+	b.WriteString("rxmerr.multi{errors: [")
+	for i, err := range e.Unwrap() {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%T(%q)", err, err.Error())
+	}
+	b.WriteString("]}")
+	return b.String()
+}
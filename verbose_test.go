@@ -0,0 +1,220 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+// stackErr mimics a github.com/pkg/errors error: %+v appends a stack trace
+// that Error() leaves out.
+type stackErr struct{ msg string }
+
+func (e *stackErr) Error() string { return e.msg }
+
+func (e *stackErr) Format(f fmt.State, verb rune) {
+	io.WriteString(f, e.msg)
+	if verb == 'v' && f.Flag('+') {
+		io.WriteString(f, "\nmain.run\n\tmain.go:12")
+	}
+}
+
+func TestCombineVerboseFormat(t *testing.T) {
+	err := rxmerr.CombineVerbose(errors.New("a"), &stackErr{msg: "b"})
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", "a; b"},
+		{"%s", "a; b"},
+		{"%q", `"a; b"`},
+		{"%+v", "2 errors occurred:\n  [0] a\n  [1] b\n      main.run\n      \tmain.go:12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, err); got != tt.want {
+				t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCombineVerboseSingle(t *testing.T) {
+	err := rxmerr.CombineVerbose(nil, errors.New("a"))
+	if got, want := fmt.Sprintf("%+v", err), "1 error occurred:\n  [0] a"; got != want {
+		t.Errorf("Sprintf(%%+v) = %q, want %q", got, want)
+	}
+}
+
+func TestCombineVerboseNil(t *testing.T) {
+	if err := rxmerr.CombineVerbose(nil, nil); err != nil {
+		t.Errorf("CombineVerbose(nil, nil) = %v, want nil", err)
+	}
+}
+
+func TestCombineVerboseUnwrap(t *testing.T) {
+	leaf := &stackErr{msg: "b"}
+	err := rxmerr.CombineVerbose(io.EOF, leaf)
+
+	if !errors.Is(err, io.EOF) {
+		t.Error("errors.Is(err, io.EOF) = false, want true")
+	}
+	var target *stackErr
+	if !errors.As(err, &target) || target != leaf {
+		t.Errorf("errors.As(err, *stackErr) = %v, want %v", target, leaf)
+	}
+	if got := len(rxmerr.Errors(err)); got != 2 {
+		t.Errorf("len(Errors(err)) = %d, want 2", got)
+	}
+}
+
+func TestCombineDebugGoString(t *testing.T) {
+	err := rxmerr.CombineDebug(errors.New("a"), fmt.Errorf("b: %w", io.EOF))
+
+	want := `rxmerr.multi{errors: [*errors.errorString("a"), *fmt.wrapError("b: EOF")]}`
+	if got := fmt.Sprintf("%#v", err); got != want {
+		t.Errorf("Sprintf(%%#v) = %s, want %s", got, want)
+	}
+	for _, msg := range []string{`"a"`, `"b: EOF"`} {
+		if !strings.Contains(fmt.Sprintf("%#v", err), msg) {
+			t.Errorf("Sprintf(%%#v) does not contain %s", msg)
+		}
+	}
+}
+
+func TestCombineDebugOtherVerbs(t *testing.T) {
+	err := rxmerr.CombineDebug(errors.New("a"), errors.New("b"))
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", "a; b"},
+		{"%s", "a; b"},
+		{"%q", `"a; b"`},
+		{"%+v", "2 errors occurred:\n  [0] a\n  [1] b"},
+	}
+
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, err); got != tt.want {
+			t.Errorf("Sprintf(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+	if rxmerr.CombineDebug(nil) != nil {
+		t.Error("CombineDebug(nil) != nil")
+	}
+}
+
+// collapsed returns the stored entry of a run of n appends of err.
+func collapsed(err error, n int) error {
+	c := rxmerr.NewCollector(rxmerr.CollapseConsecutive())
+	for range n {
+		c.Append(err)
+	}
+	return c.Errors()[0]
+}
+
+func TestWrapperFormat(t *testing.T) {
+	leaf := &stackErr{msg: "upstream overloaded"}
+
+	tests := []struct {
+		name        string
+		err         error
+		v, plusV, q string
+	}{
+		{
+			name:  "HTTPError",
+			err:   &rxmerr.HTTPError{StatusCode: 503, Body: "busy", Err: leaf},
+			v:     "503 Service Unavailable: busy: upstream overloaded",
+			plusV: "503 Service Unavailable: busy: upstream overloaded\nmain.run\n\tmain.go:12",
+			q:     `"503 Service Unavailable: busy: upstream overloaded"`,
+		},
+		{
+			name:  "HTTPError without cause",
+			err:   &rxmerr.HTTPError{StatusCode: 404},
+			v:     "404 Not Found",
+			plusV: "404 Not Found",
+			q:     `"404 Not Found"`,
+		},
+		{
+			name:  "repeated",
+			err:   collapsed(leaf, 3),
+			v:     "upstream overloaded (repeated 2 times)",
+			plusV: "upstream overloaded (repeated 2 times)\nmain.run\n\tmain.go:12",
+			q:     `"upstream overloaded (repeated 2 times)"`,
+		},
+		{
+			name:  "truncated",
+			err:   rxmerr.TruncateMessage(leaf, 8),
+			v:     "upstream…(truncated, 19B total)",
+			plusV: "upstream…(truncated, 19B total)\nmain.run\n\tmain.go:12",
+			q:     `"upstream…(truncated, 19B total)"`,
+		},
+		{
+			name:  "truncated plain",
+			err:   rxmerr.TruncateMessage(errors.New("upstream overloaded"), 8),
+			v:     "upstream…(truncated, 19B total)",
+			plusV: "upstream…(truncated, 19B total)",
+			q:     `"upstream…(truncated, 19B total)"`,
+		},
+		{
+			name:  "truncated messages",
+			err:   rxmerr.Errors(rxmerr.TruncateMessages(rxmerr.Combine(leaf, io.EOF), 8))[0],
+			v:     "upstream…",
+			plusV: "upstream…\nmain.run\n\tmain.go:12",
+			q:     `"upstream…"`,
+		},
+		{
+			name: "aggregate",
+			err: rxmerr.CombineVerbose(
+				&rxmerr.HTTPError{StatusCode: 502, Err: leaf},
+				collapsed(leaf, 2),
+			),
+			v: "502 Bad Gateway: upstream overloaded; upstream overloaded (repeated 1 times)",
+			plusV: "2 errors occurred:\n" +
+				"  [0] 502 Bad Gateway: upstream overloaded\n      main.run\n      \tmain.go:12\n" +
+				"  [1] upstream overloaded (repeated 1 times)\n      main.run\n      \tmain.go:12",
+			q: `"502 Bad Gateway: upstream overloaded; upstream overloaded (repeated 1 times)"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, f := range []struct{ format, want string }{
+				{"%v", tt.v},
+				{"%s", tt.v},
+				{"%+v", tt.plusV},
+				{"%q", tt.q},
+			} {
+				if got := fmt.Sprintf(f.format, tt.err); got != f.want {
+					t.Errorf("Sprintf(%q) = %q, want %q", f.format, got, f.want)
+				}
+			}
+			if got := fmt.Sprintf("%v", tt.err); strings.Contains(got, "main.run") {
+				t.Errorf("%%v = %q, want no stack trace", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+// View is a read-only window onto a Collector.
+//
+// A View exposes only the inspection methods of the Collector it was created
+// from, so it can be handed to helper packages that need to look at collected
+// errors without being able to add to or reset them. Ownership of mutation
+// stays with the code that holds the *Collector:
+//
+//	c := rxmerr.NewCollector()
+//	report(c.View()) // report can inspect, but not Append
+//
+// A View is backed by the live collector, not by a snapshot: errors appended
+// to the collector after the View was created are visible through it.
+//
+// # Concurrency
+//
+// View adds no synchronization of its own. Because Collector is NOT safe for
+// concurrent use, reading through a View while another goroutine appends to
+// the underlying collector is a data race; callers MUST synchronize such
+// access themselves.
+//
+// The zero View is not backed by any collector and behaves as an empty one.
+type View struct {
+	c *Collector
+}
+
+// View returns a read-only View backed by c.
+func (c *Collector) View() View {
+	return View{c: c}
+}
+
+// Err returns the aggregated error of the underlying collector.
+// See Collector.Err.
+func (v View) Err() error {
+	if v.c == nil {
+		return nil
+	}
+	return v.c.Err()
+}
+
+// Len returns the number of collected errors.
+// See Collector.Len.
+func (v View) Len() int {
+	if v.c == nil {
+		return 0
+	}
+	return v.c.Len()
+}
+
+// HasError reports whether at least one error was collected.
+// See Collector.HasError.
+func (v View) HasError() bool {
+	return v.c != nil && v.c.HasError()
+}
+
+// Errors returns all collected errors as a slice.
+// See Collector.Errors.
+func (v View) Errors() []error {
+	if v.c == nil {
+		return nil
+	}
+	return v.c.Errors()
+}
+
+// Each calls fn for every collected error until fn returns false.
+// See Collector.Each.
+func (v View) Each(fn func(err error) bool) {
+	if v.c != nil {
+		v.c.Each(fn)
+	}
+}
+
+// Contains reports whether any collected error matches target.
+// See Collector.Contains.
+func (v View) Contains(target error) bool {
+	return v.c != nil && v.c.Contains(target)
+}
@@ -0,0 +1,100 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestViewIsLive(t *testing.T) {
+	c := rxmerr.NewCollector()
+	v := c.View()
+
+	if v.HasError() || v.Len() != 0 || v.Err() != nil || v.Errors() != nil {
+		t.Fatal("View of an empty collector reports errors")
+	}
+
+	c.Append(io.EOF)
+	c.Append(errors.New("second"))
+
+	if !v.HasError() {
+		t.Error("HasError() = false after Append, want true")
+	}
+	if got := v.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+	if got, want := v.Err().Error(), "EOF; second"; got != want {
+		t.Errorf("Err() = %q, want %q", got, want)
+	}
+	if !v.Contains(io.EOF) {
+		t.Error("Contains(io.EOF) = false, want true")
+	}
+	if got := v.Errors(); len(got) != 2 || got[0] != io.EOF {
+		t.Errorf("Errors() = %v, want [EOF second]", got)
+	}
+
+	c.Reset()
+	if v.HasError() {
+		t.Error("HasError() = true after Reset, want false")
+	}
+}
+
+func TestViewEach(t *testing.T) {
+	c := rxmerr.NewCollector()
+	c.Append(errors.New("a"), errors.New("b"), errors.New("c"))
+
+	var seen []string
+	c.View().Each(func(err error) bool {
+		seen = append(seen, err.Error())
+		return len(seen) < 2
+	})
+	if fmt.Sprint(seen) != "[a b]" {
+		t.Errorf("Each visited %v, want [a b]", seen)
+	}
+}
+
+func TestZeroView(t *testing.T) {
+	var v rxmerr.View
+
+	if v.HasError() || v.Len() != 0 || v.Err() != nil || v.Errors() != nil || v.Contains(io.EOF) {
+		t.Error("zero View is not empty")
+	}
+	v.Each(func(error) bool {
+		t.Error("Each called fn on a zero View")
+		return true
+	})
+}
+
+func ExampleCollector_View() {
+	c := rxmerr.NewCollector()
+	report := func(v rxmerr.View) {
+		fmt.Println(v.Len(), "errors:", v.Err())
+	}
+
+	c.Append(errors.New("disk full"))
+	report(c.View())
+	c.Append(errors.New("quota exceeded"))
+	report(c.View())
+	// Output:
+	// 1 errors: disk full
+	// 2 errors: disk full; quota exceeded
+}
@@ -0,0 +1,76 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr
+
+// AppendWeighted adds err to the collector with the given weight.
+//
+// Weights express severity: a fatal error can count more than a warning
+// toward the failure threshold set with WithWeightBudget. Errors appended
+// with Append have a weight of 1. Negative weights are treated as 0.
+//
+// err goes through the same pipeline as with Append; if err is nil or is
+// discarded by an option, its weight is not counted.
+func (c *Collector) AppendWeighted(weight int, err error) {
+	if err != nil {
+		callDebugHook(err)
+		c.add(err, max(weight, 0))
+	}
+}
+
+// TotalWeight returns the total weight of the errors collected so far. See
+// AppendWeighted.
+//
+// Reset sets the total weight to zero. Destructive edits such as TruncateAt
+// and SwapAt do not track individual weights and leave it unchanged.
+func (c *Collector) TotalWeight() int {
+	return c.weight
+}
+
+// WithWeightBudget returns an option that sets the failure threshold checked
+// by OverBudget to budget. A non-positive budget disables the threshold.
+//
+// The budget does not affect collection: errors are still stored and counted
+// after it is exceeded.
+func WithWeightBudget(budget int) Option {
+	return func(c *Collector) {
+		c.weightBudget = budget
+	}
+}
+
+// NewCollectorWeightBudget creates a Collector with a weight budget. It is
+// shorthand for:
+//
+//	rxmerr.NewCollector(rxmerr.WithWeightBudget(budget))
+//
+// For example, with a budget of 10, ten warnings of weight 1 are tolerated,
+// while a single fatal error of weight 20 is not:
+//
+//	c := rxmerr.NewCollectorWeightBudget(10)
+//	c.AppendWeighted(1, warning)
+//	c.AppendWeighted(20, fatal)
+//	if c.OverBudget() {
+//	    return c.Err()
+//	}
+func NewCollectorWeightBudget(budget int) *Collector {
+	return NewCollector(WithWeightBudget(budget))
+}
+
+// OverBudget reports whether TotalWeight exceeds the budget set with
+// WithWeightBudget. It always returns false if no budget is set.
+func (c *Collector) OverBudget() bool {
+	return c.weightBudget > 0 && c.weight > c.weightBudget
+}
@@ -0,0 +1,87 @@
+/*
+	Copyright 2025 The DIRPX Authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package rxmerr_test
+
+import (
+	"errors"
+	"testing"
+
+	"dirpx.dev/rxmerr"
+)
+
+func TestWeightBudget(t *testing.T) {
+	warning := errors.New("slow upstream")
+	fatal := errors.New("upstream down")
+
+	c := rxmerr.NewCollectorWeightBudget(10)
+	steps := []struct {
+		weight int
+		err    error
+		total  int
+		over   bool
+	}{
+		{1, warning, 1, false},
+		{1, nil, 1, false},
+		{-5, warning, 1, false},
+		{8, warning, 9, false},
+		{1, warning, 10, false},
+		{20, fatal, 30, true},
+	}
+	for i, s := range steps {
+		c.AppendWeighted(s.weight, s.err)
+		if got := c.TotalWeight(); got != s.total {
+			t.Errorf("step %d: TotalWeight() = %d, want %d", i, got, s.total)
+		}
+		if got := c.OverBudget(); got != s.over {
+			t.Errorf("step %d: OverBudget() = %v, want %v", i, got, s.over)
+		}
+	}
+	if got, want := c.Len(), 5; got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+
+	c.Reset()
+	if c.TotalWeight() != 0 || c.OverBudget() {
+		t.Errorf("after Reset: TotalWeight() = %d, OverBudget() = %v", c.TotalWeight(), c.OverBudget())
+	}
+}
+
+func TestWeightBudgetMixed(t *testing.T) {
+	c := rxmerr.NewCollector(
+		rxmerr.WithWeightBudget(3),
+		rxmerr.WithIgnore(func(err error) bool { return err.Error() == "ignored" }),
+	)
+	c.Append(errors.New("a"), errors.New("b"))
+	c.AppendWeighted(5, errors.New("ignored"))
+	if got := c.TotalWeight(); got != 2 {
+		t.Errorf("TotalWeight() = %d, want 2: discarded errors must not count", got)
+	}
+	c.AppendWeighted(2, errors.New("c"))
+	if !c.OverBudget() {
+		t.Errorf("OverBudget() = false with TotalWeight() = %d", c.TotalWeight())
+	}
+}
+
+func TestWeightBudgetDisabled(t *testing.T) {
+	for _, budget := range []int{0, -1} {
+		c := rxmerr.NewCollectorWeightBudget(budget)
+		c.AppendWeighted(100, errors.New("fatal"))
+		if c.OverBudget() {
+			t.Errorf("budget %d: OverBudget() = true, want false", budget)
+		}
+	}
+}